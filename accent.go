@@ -0,0 +1,138 @@
+package litxap
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// AccentStress is a WordFormatter that puts an acute accent on the nucleus of the stressed syllable, e.g. "kaltxí".
+// Where there is no precomposed letter, as with "ä", "ì" and "ù", a combining acute accent is added after it. The
+// pseudovowels get theirs on the first letter ("kŕr").
+func AccentStress(syllables []string, stress int) string {
+	res, _ := accentWord(syllables, stress)
+	return res
+}
+
+// FormatAccents is line.Format(s, AccentStress), except that it also gives the byte offsets of the accents it put
+// in, so that StripAccents can take out those and leave alone the ones that were already in s.
+func (line Line) FormatAccents(s string) (string, []int) {
+	sb := strings.Builder{}
+	sb.Grow(len(s) + len(s)/2)
+	var at []int
+
+	for _, part := range line.SourceParts(s) {
+		if part.Syllables == nil {
+			sb.WriteString(part.Text)
+			continue
+		}
+
+		sb.WriteString(part.Prefix())
+		word, pos := accentWord(part.Syllables, part.Stress)
+		if pos >= 0 {
+			at = append(at, sb.Len()+pos)
+		}
+		sb.WriteString(word)
+	}
+
+	return sb.String(), at
+}
+
+// StripAccents takes the accents that Line.FormatAccents put in at the offsets back out. Any other accent in s, like
+// the one in a loanword, is left alone.
+func StripAccents(s string, at []int) string {
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+
+	pos := 0
+	for _, i := range at {
+		if i < pos || i >= len(s) {
+			continue
+		}
+
+		sb.WriteString(s[pos:i])
+		pos = i
+
+		ch, size := utf8.DecodeRuneInString(s[i:])
+		if plain, ok := plainRunes[ch]; ok {
+			sb.WriteRune(plain)
+			pos += size
+		} else if s[i:i+size] == combiningAcute {
+			pos += size
+		}
+	}
+	sb.WriteString(s[pos:])
+
+	return sb.String()
+}
+
+// accentWord joins the syllables with the stressed one accented, and gives the offset of the accent, or -1 if none
+// was put in.
+func accentWord(syllables []string, stress int) (string, int) {
+	sb := strings.Builder{}
+	at := -1
+	for i, syllable := range syllables {
+		if i == stress {
+			accented, pos := accentSyllable(syllable)
+			if pos >= 0 {
+				at = sb.Len() + pos
+			}
+			sb.WriteString(accented)
+		} else {
+			sb.WriteString(syllable)
+		}
+	}
+
+	return sb.String(), at
+}
+
+// accentSyllable accents the nucleus of the syllable, and gives the offset of the accented letter or the combining
+// accent, or -1 if it has none.
+func accentSyllable(syllable string) (string, int) {
+	start, end := litxaputil.FindNucleus(syllable)
+	if start == -1 {
+		return syllable, -1
+	}
+
+	ch, size := utf8.DecodeRuneInString(syllable[start:])
+	if accented, ok := accentedRunes[ch]; ok && !hasMarks(syllable[start+size:end]) {
+		return syllable[:start] + string(accented) + syllable[start+size:], start
+	}
+	if ch == 'é' || ch == 'É' {
+		return syllable, -1
+	}
+
+	// Put the combining accent after the marks already on the letter, e.g. "ä" + U+0301.
+	markEnd := start + size
+	for markEnd < end {
+		next, nextSize := utf8.DecodeRuneInString(syllable[markEnd:])
+		if next < 0x300 || next > 0x36f {
+			break
+		}
+		markEnd += nextSize
+	}
+
+	return syllable[:markEnd] + combiningAcute + syllable[markEnd:], markEnd
+}
+
+func hasMarks(s string) bool {
+	next, _ := utf8.DecodeRuneInString(s)
+	return next >= 0x300 && next <= 0x36f
+}
+
+const combiningAcute = "\u0301"
+
+var accentedRunes = map[rune]rune{
+	'a': 'á', 'e': 'é', 'i': 'í', 'o': 'ó', 'u': 'ú', 'l': 'ĺ', 'r': 'ŕ',
+	'A': 'Á', 'E': 'É', 'I': 'Í', 'O': 'Ó', 'U': 'Ú', 'L': 'Ĺ', 'R': 'Ŕ',
+}
+
+var plainRunes = func() map[rune]rune {
+	res := make(map[rune]rune, len(accentedRunes))
+	for plain, accented := range accentedRunes {
+		res[accented] = plain
+	}
+
+	return res
+}()
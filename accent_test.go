@@ -0,0 +1,63 @@
+package litxap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccentStress(t *testing.T) {
+	table := []struct {
+		syllables []string
+		stress    int
+		expected  string
+	}{
+		{[]string{"kal", "txì"}, 1, "kaltxì\u0301"},
+		{[]string{"Kal", "txì"}, 0, "Káltxì"},
+		{[]string{"tì", "fme", "tok"}, 1, "tìfmétok"},
+		{[]string{"sä", "keyn", "ven"}, 0, "sä\u0301keynven"},
+		{[]string{"'awn"}, 0, "'áwn"},
+		{[]string{"krr"}, 0, "kŕr"},
+		{[]string{"KXLL", "Ä"}, 0, "KXĹLÄ"},
+		{[]string{"ù", "ran"}, 0, "ù\u0301ran"},
+		{[]string{"ta\u0308", "ron"}, 0, "ta\u0308\u0301ron"},
+		{[]string{"ng"}, 0, "ng"},
+		{[]string{"kal", "txì"}, -1, "kaltxì"},
+		{[]string{"é"}, 0, "é"},
+	}
+
+	for _, row := range table {
+		t.Run(row.expected, func(t *testing.T) {
+			res := AccentStress(row.syllables, row.stress)
+			assert.Equal(t, row.expected, res)
+		})
+	}
+}
+
+func TestStripAccents(t *testing.T) {
+	input := "Kaltxì, ma fmetokyu! Oel ngati kameie, krr sä'u."
+	line, err := RunLine(input, dummyDictionary)
+	assert.NoError(t, err)
+
+	accented, at := line.FormatAccents(input)
+	assert.Equal(t, "Kaltxì\u0301, má fmétokyu! Óel ngáti kámeie, krr sä'u.", accented)
+	assert.Equal(t, line.Format(input, AccentStress), accented)
+	assert.Equal(t, []int{7, 12, 17, 26, 33, 39}, at)
+	assert.Equal(t, input, StripAccents(accented, at))
+}
+
+func TestStripAccents_KeepsOtherAccents(t *testing.T) {
+	input := "Oel tséko \"café\" kame, Kaltxí."
+	dict := MapDictionary{
+		"oel":   {*ParseEntry("o.e: -l: I")},
+		"tséko": {*ParseEntry("tsé.ko: : test")},
+		"kame":  {*ParseEntry("k·a.m·e: : see")},
+	}
+	line, err := RunLine(input, dict)
+	assert.NoError(t, err)
+
+	accented, at := line.FormatAccents(input)
+	assert.Equal(t, "Óel tséko \"café\" káme, Kaltxí.", accented)
+	assert.Equal(t, input, StripAccents(accented, at))
+	assert.Equal(t, "café", StripAccents("café", []int{1, 99}))
+}
@@ -0,0 +1,47 @@
+package litxap
+
+import (
+	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// CaseForm is a noun in a case, with the suffix that was picked for it.
+type CaseForm struct {
+	// Suffix is the allomorph, e.g. "ìl". It's empty for the subjective.
+	Suffix string `json:"suffix"`
+	// Entry is the entry with the suffix added to its Suffixes.
+	Entry Entry `json:"entry"`
+	// Word is the spelling of the form, e.g. "taronìl".
+	Word      string   `json:"word"`
+	Syllables []string `json:"syllables"`
+	Stress    int      `json:"stress"`
+}
+
+// CaseForms puts the entry in the case, with one form for each allomorph that fits the end of the word, the most
+// common one first. The suffix goes after any suffixes the entry already has.
+func CaseForms(entry Entry, c litxaputil.Case) ([]CaseForm, error) {
+	syllables, stress, _, err := entry.GenerateSyllablesE()
+	if err != nil {
+		return nil, err
+	}
+	if c == litxaputil.CaseSubjective {
+		return []CaseForm{{Entry: entry, Word: strings.Join(syllables, ""), Syllables: syllables, Stress: stress}}, nil
+	}
+
+	suffixes := litxaputil.CaseSuffixes(c, syllables)
+	res := make([]CaseForm, 0, len(suffixes))
+	for _, suffix := range suffixes {
+		form := entry
+		form.Suffixes = append(entry.Suffixes[:len(entry.Suffixes):len(entry.Suffixes)], suffix)
+
+		syllables, stress, _, err := form.GenerateSyllablesE()
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, CaseForm{Suffix: suffix, Entry: form, Word: strings.Join(syllables, ""), Syllables: syllables, Stress: stress})
+	}
+
+	return res, nil
+}
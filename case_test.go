@@ -0,0 +1,59 @@
+package litxap
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseForms(t *testing.T) {
+	table := []struct {
+		entry    string
+		c        litxaputil.Case
+		expected []string
+	}{
+		{"ta.*ron: hunt", litxaputil.CaseSubjective, []string{"ta.*ron"}},
+		{"ta.*ron: hunt", litxaputil.CaseAgentive, []string{"ìl ta.*ro.nìl"}},
+		{"tu.te: person", litxaputil.CaseAgentive, []string{"l tu.tel"}},
+		{"tu.te: person", litxaputil.CasePatientive, []string{"t tu.tet", "ti tu.te.ti"}},
+		{"tu.te: ay-: person", litxaputil.CaseDative, []string{"r ay.*su.ter", "ru ay.*su.te.ru"}},
+		{"'a.wak: one", litxaputil.CaseTopical, []string{"ìri 'a.wa.kì.ri"}},
+		{"fme.tok: -tsyìp: test", litxaputil.CaseGenitive, []string{"ä fme.tok.tsyì.pä", "e fme.tok.tsyì.pe"}},
+		{"kel.ku: home", litxaputil.CaseGenitive, []string{"ä kel.ku.ä", "e kel.ku.e"}},
+		{"tsko: bow", litxaputil.CaseGenitive, []string{"ä tsko.ä", "e tsko.e"}},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry+" "+row.c.String(), func(t *testing.T) {
+			forms, err := CaseForms(*ParseEntry(row.entry), row.c)
+			assert.NoError(t, err)
+
+			res := make([]string, 0, len(forms))
+			for _, form := range forms {
+				word := DotSyllables(form.Syllables, form.Stress)
+				if form.Suffix != "" {
+					word = form.Suffix + " " + word
+				}
+				res = append(res, word)
+			}
+			assert.Equal(t, row.expected, res)
+		})
+	}
+}
+
+func TestCaseForms_KeepsEntry(t *testing.T) {
+	entry := Entry{Word: "fmetok", Syllables: []string{"fme", "tok"}, Suffixes: make([]string, 1, 4)}
+	entry.Suffixes[0] = "tsyìp"
+
+	forms, err := CaseForms(entry, litxaputil.CaseGenitive)
+	assert.NoError(t, err)
+	assert.Len(t, forms, 2)
+	assert.Equal(t, "fmetoktsyìpä", forms[0].Word)
+	assert.Equal(t, []string{"tsyìp", "ä"}, forms[0].Entry.Suffixes)
+	assert.Equal(t, []string{"tsyìp", "e"}, forms[1].Entry.Suffixes)
+	assert.Equal(t, []string{"tsyìp"}, entry.Suffixes)
+
+	_, err = CaseForms(Entry{Word: "blarg"}, litxaputil.CaseAgentive)
+	assert.ErrorIs(t, err, litxaputil.ErrEmptyWord)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+func runAnalyze(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: litxap -dict FILE [-format text|json|ndjson] [FILE...]")
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	format := flags.String("format", "text", "output format: text, json or ndjson")
+	compact := flags.Bool("compact", false, "deduplicate the entries in json output")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if *format != "text" && *format != "json" && *format != "ndjson" {
+		printError(stderr, fmt.Errorf("unknown format %q", *format))
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	bw := bufio.NewWriter(stdout)
+	defer bw.Flush()
+	encoder := json.NewEncoder(bw)
+
+	var lines []litxap.Line
+	lineNo := 0
+	hasUnknown := false
+	err = eachLine(flags.Args(), stdin, func(s string) error {
+		lineNo++
+
+		line, err := litxap.RunLine(s, dict)
+		if err != nil {
+			return &dictionaryError{err: err}
+		}
+
+		if unknown := line.Unknown(); len(unknown) > 0 {
+			hasUnknown = true
+			fmt.Fprintf(stderr, "line %d: unknown words: %s\n", lineNo, strings.Join(unknown, ", "))
+		}
+
+		switch *format {
+		case "text":
+			bw.WriteString(line.Format(s, litxap.AccentStress))
+			bw.WriteByte('\n')
+		case "ndjson":
+			return encoder.Encode(line)
+		case "json":
+			lines = append(lines, line)
+		}
+
+		return nil
+	})
+	if err != nil {
+		printError(stderr, err)
+		if errors.As(err, new(*dictionaryError)) {
+			return exitDictionary
+		}
+
+		return exitError
+	}
+
+	if *format == "json" {
+		if err := encoder.Encode(litxap.EncodeLines(lines, *compact)); err != nil {
+			printError(stderr, err)
+			return exitError
+		}
+	}
+
+	if hasUnknown {
+		return exitUnknownWords
+	}
+
+	return exitOK
+}
+
+// dictionaryError is a failed lookup, which is told apart from I/O errors by the exit code.
+type dictionaryError struct {
+	err error
+}
+
+func (e *dictionaryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *dictionaryError) Unwrap() error {
+	return e.err
+}
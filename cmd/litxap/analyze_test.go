@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunAnalyze(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	code, stdout, stderr := runCommand(t, "Kaltxì, ma fmetokyu!\nOel ngati kameie.\n", "-dict", dict)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "Kaltxì\u0301, má fmétokyu!\nÓel ngáti kameie.\n", stdout)
+	assert.Empty(t, stderr)
+
+	code, stdout, stderr = runCommand(t, "Kaltxì, ma tsmukan!\n", "-dict", dict)
+	assert.Equal(t, exitUnknownWords, code)
+	assert.Equal(t, "Kaltxì\u0301, má tsmukan!\n", stdout)
+	assert.Equal(t, "line 1: unknown words: tsmukan\n", stderr)
+}
+
+func TestRunAnalyze_Files(t *testing.T) {
+	dict := writeTestDictionary(t)
+	input := filepath.Join(t.TempDir(), "input.txt")
+	assert.NoError(t, os.WriteFile(input, []byte("Ma fmetokyu\r\n"), 0644))
+
+	code, stdout, _ := runCommand(t, "", "-dict", dict, input)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "Má fmétokyu\n", stdout)
+
+	code, _, stderr := runCommand(t, "", "-dict", dict, input+".missing")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "no such file")
+}
+
+func TestRunAnalyze_JSON(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	for _, compact := range []bool{false, true} {
+		args := []string{"-dict", dict, "-format", "json"}
+		if compact {
+			args = append(args, "-compact")
+		}
+
+		code, stdout, _ := runCommand(t, "Ma fmetokyu\nma\n", args...)
+		assert.Equal(t, exitOK, code)
+
+		var env litxap.LinesEnvelope
+		assert.NoError(t, json.Unmarshal([]byte(stdout), &env))
+		assert.Equal(t, compact, env.Compact != nil)
+
+		lines, err := env.Decode()
+		assert.NoError(t, err)
+		assert.Len(t, lines, 2)
+		assert.Equal(t, []string{"fme", "tok", "yu"}, lines[0][2].Matches[0].Syllables)
+	}
+}
+
+func TestRunAnalyze_NDJSON(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	code, stdout, _ := runCommand(t, "Ma fmetokyu\nkaltxì\n", "-dict", dict, "-format", "ndjson")
+	assert.Equal(t, exitOK, code)
+
+	rows := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, rows, 2)
+
+	var line litxap.Line
+	assert.NoError(t, json.Unmarshal([]byte(rows[1]), &line))
+	assert.Equal(t, 1, line[0].Matches[0].Stress)
+}
+
+func TestRunAnalyze_Fail(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0644))
+
+	code, _, stderr := runCommand(t, "kaltxì\n")
+	assert.Equal(t, exitDictionary, code)
+	assert.Contains(t, stderr, "no dictionary")
+
+	code, _, _ = runCommand(t, "kaltxì\n", "-dict", filepath.Join(dir, "bad.json"))
+	assert.Equal(t, exitDictionary, code)
+
+	code, _, _ = runCommand(t, "kaltxì\n", "-dict", filepath.Join(dir, "missing.txt"))
+	assert.Equal(t, exitDictionary, code)
+
+	code, _, stderr = runCommand(t, "kaltxì\n", "-dict", writeTestDictionary(t), "-format", "xml")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "unknown format")
+
+	code, _, _ = runCommand(t, "kaltxì\n", "-nope")
+	assert.Equal(t, exitError, code)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/flashcard"
+)
+
+func runCards(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap cards", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: litxap cards -dict FILE [-tsv] [-all] [-mark accent|upper|TEXT] [FILE...]
+
+Writes a flashcard deck as CSV, with one card for each distinct word in the text: the word with its stress marked,
+the syllables, IPA, root, affixes, translation and the line it was first seen in. With -all, the deck is made from
+every entry in the dictionaries instead.`)
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	tsv := flags.Bool("tsv", false, "separate the columns with tabs")
+	all := flags.Bool("all", false, "make cards of every entry in the dictionaries, and read no text")
+	mark := flags.String("mark", "accent", `"accent" for an acute accent, "upper" for uppercase, or text to put in front of the syllable`)
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	format := markFormatter(*mark)
+	if format == nil {
+		printError(stderr, errors.New("the mark can not be empty"))
+		return exitError
+	}
+
+	deck := flashcard.NewDeck(format)
+	code := exitOK
+	if *all {
+		entries, err := dicts.entries()
+		if err != nil {
+			printError(stderr, err)
+			return exitDictionary
+		}
+
+		// The entries that can't be generated are left out of the deck, but the rest of it is still written.
+		if err := deck.AddEntries(entries); err != nil {
+			printError(stderr, err)
+			code = exitDictionary
+		}
+	} else {
+		dict, err := dicts.load()
+		if err != nil {
+			printError(stderr, err)
+			return exitDictionary
+		}
+
+		err = eachLine(flags.Args(), stdin, func(s string) error {
+			line, err := litxap.RunLine(s, dict)
+			if err != nil {
+				return &dictionaryError{err: err}
+			}
+
+			deck.AddLine(s, line)
+			return nil
+		})
+		if err != nil {
+			printError(stderr, err)
+
+			if errors.As(err, new(*dictionaryError)) {
+				return exitDictionary
+			}
+
+			return exitError
+		}
+	}
+
+	comma := ','
+	if *tsv {
+		comma = '\t'
+	}
+	if err := deck.Write(stdout, comma); err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+
+	return code
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCards(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	code, stdout, stderr := runCommand(t, "Kaltxì, ma fmetokyu!\nfmetokyu tsmukan\nOel ngati kameie.\n", "cards", "-dict", dict, "-mark", "upper")
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"surface", "syllables", "ipa", "root", "affixes", "translation", "source"},
+		{"KalTXÌ", "Kal.txì", "kal.ˈt'ɪ", "kaltxì", "", "hello", "Kaltxì, ma fmetokyu!"},
+		{"MA", "ma", "ma", "ma", "", "oh", "Kaltxì, ma fmetokyu!"},
+		{"FMEtokyu", "fme.tok.yu", "ˈfmɛ.tok̚.ju", "fmetok", "-yu", "tester", "Kaltxì, ma fmetokyu!"},
+		{"Oel", "O.el", "ˈo.ɛl", "oe", "-l", "I", "Oel ngati kameie."},
+		{"NGAti", "nga.ti", "ˈŋa.ti", "nga", "-ti", "you", "Oel ngati kameie."},
+		{"KAmeie", "ka.me.i.e", "ˈka.mɛ.i.ɛ", "kame", "<ei>", "see", "Oel ngati kameie."},
+		{"kameiE", "ka.me.i.e", "ka.mɛ.i.ˈɛ", "kä", "<am,ei>", "go", "Oel ngati kameie."},
+	}, records)
+}
+
+func TestRunCards_All(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	code, stdout, _ := runCommand(t, "", "cards", "-dict", dict, "-all", "-tsv")
+	assert.Equal(t, exitOK, code)
+
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	assert.Equal(t, "surface\tsyllables\tipa\troot\taffixes\ttranslation\tsource", lines[0])
+	assert.Contains(t, lines, "ngáti\tnga.ti\tˈŋa.ti\tnga\t-ti\tyou\t")
+	assert.Len(t, lines, 8)
+
+	code, _, _ = runCommand(t, "", "cards", "-all")
+	assert.Equal(t, exitDictionary, code)
+}
+
+func TestRunCards_AllInvalid(t *testing.T) {
+	dict := filepath.Join(t.TempDir(), "words.json")
+	data := `{"taron": [{"word": "taron", "syllables": ["ta", "ron"], "prefixes": ["fnetsa"]}, {"word": "taron", "syllables": ["ta", "ron"]}]}`
+	assert.NoError(t, os.WriteFile(dict, []byte(data), 0644))
+
+	code, stdout, stderr := runCommand(t, "", "cards", "-dict", dict, "-all", "-tsv")
+	assert.Equal(t, exitDictionary, code)
+	assert.Equal(t, "litxap: ta.ron: fnetsa-: prefix \"fnetsa\": unknown affix\n", stderr)
+	assert.Len(t, strings.Split(strings.TrimSuffix(stdout, "\n"), "\n"), 2)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gissleh/litxap/golden"
+)
+
+func runCheck(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: litxap check -dict FILE [-update] GOLDEN...
+
+Runs the lines in the golden files, and shows where the results differ from the expected ones. It exits with 4 if
+any did. With -update, the golden files are rewritten with the new results instead.`)
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	update := flags.Bool("update", false, "rewrite the golden files with the current results")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	code := exitOK
+	for _, name := range flags.Args() {
+		file, err := readGoldenFile(name)
+		if err != nil {
+			printError(stderr, err)
+			return exitError
+		}
+
+		results, err := file.Check(dict)
+		if err != nil {
+			printError(stderr, fmt.Errorf("%s: %w", name, err))
+			return exitDictionary
+		}
+
+		failed, err := golden.WriteDiff(stdout, name, results)
+		if err != nil {
+			printError(stderr, err)
+			return exitError
+		}
+		if failed == 0 {
+			continue
+		}
+
+		if !*update {
+			code = exitChanged
+			continue
+		}
+
+		file.Update(results)
+		buf := bytes.Buffer{}
+		if _, err := file.WriteTo(&buf); err != nil {
+			printError(stderr, err)
+			return exitError
+		}
+		if err := os.WriteFile(name, buf.Bytes(), 0644); err != nil {
+			printError(stderr, err)
+			return exitError
+		}
+
+		fmt.Fprintf(stdout, "updated %s\n", name)
+	}
+
+	return code
+}
+
+func readGoldenFile(name string) (*golden.File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return golden.Read(f)
+}
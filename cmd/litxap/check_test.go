@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCheck(t *testing.T) {
+	dict := writeTestDictionary(t)
+	path := filepath.Join(t.TempDir(), "golden.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# test\nKaltxì, ma fmetokyu!\tKal.*txì ma fme.tok.yu\nOel kameie\to.el ka.me.i.e\n"), 0644))
+
+	code, stdout, _ := runCommand(t, "", "check", "-dict", dict, path)
+	assert.Equal(t, exitChanged, code)
+	assert.Equal(t, path+":3: Oel kameie\n"+
+		"-\to.el ka.me.i.e\n"+
+		"+\tO.el ka.me.i.e|ka.me.i.*e\n"+
+		"\tword 1: o.el -> O.el\n"+
+		"\tword 2: ka.me.i.e -> ka.me.i.e|ka.me.i.*e\n"+
+		"1 of 2 lines changed\n", stdout)
+
+	code, stdout, _ = runCommand(t, "", "check", "-dict", dict, "-update", path)
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "updated "+path+"\n")
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "# test\nKaltxì, ma fmetokyu!\tKal.*txì ma fme.tok.yu\nOel kameie\tO.el ka.me.i.e|ka.me.i.*e\n", string(data))
+
+	code, stdout, _ = runCommand(t, "", "check", "-dict", dict, path)
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stdout)
+
+	code, _, _ = runCommand(t, "", "check", "-dict", dict, path+".missing")
+	assert.Equal(t, exitError, code)
+
+	code, _, _ = runCommand(t, "", "check", "-dict", dict)
+	assert.Equal(t, exitError, code)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+func runDecline(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap decline", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: litxap decline [-mark accent|upper|TEXT] NOTATION
+
+Shows the noun in the notation, e.g. "tu.te: person", in every case and number. Where a case has more than one
+suffix that fits, the forms are separated by slashes.`)
+		flags.PrintDefaults()
+	}
+	mark := flags.String("mark", "accent", `"accent" for an acute accent, "upper" for uppercase, or text to put in front of the syllable`)
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	format := markFormatter(*mark)
+	if format == nil {
+		printError(stderr, errors.New("the mark can not be empty"))
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	rows, err := litxap.Decline(*litxap.ParseEntry(flags.Arg(0)))
+	if err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintf(tw, "\t%s", row.Number)
+	}
+	fmt.Fprintln(tw)
+
+	for c := litxaputil.CaseSubjective; c <= litxaputil.CaseTopical; c++ {
+		fmt.Fprint(tw, c)
+		for _, row := range rows {
+			words := make([]string, 0, len(row.Cases[c]))
+			for _, form := range row.Cases[c] {
+				words = append(words, format(form.Syllables, form.Stress))
+			}
+
+			fmt.Fprintf(tw, "\t%s", strings.Join(words, " / "))
+		}
+		fmt.Fprintln(tw)
+	}
+
+	tw.Flush()
+	return exitOK
+}
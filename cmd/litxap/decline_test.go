@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDecline(t *testing.T) {
+	code, stdout, _ := runCommand(t, "", "decline", "-mark", "upper", "tsmu.kan: sibling")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, `            singular               dual                     trial                      plural                   short plural
+subjective  TSMUkan                meSMUkan                 pxeSMUkan                  aySMUkan                 SMUkan
+agentive    TSMUkanìl              meSMUkanìl               pxeSMUkanìl                aySMUkanìl               SMUkanìl
+patientive  TSMUkanit / TSMUkanti  meSMUkanit / meSMUkanti  pxeSMUkanit / pxeSMUkanti  aySMUkanit / aySMUkanti  SMUkanit / SMUkanti
+dative      TSMUkanur              meSMUkanur               pxeSMUkanur                aySMUkanur               SMUkanur
+genitive    TSMUkanä / TSMUkane    meSMUkanä / meSMUkane    pxeSMUkanä / pxeSMUkane    aySMUkanä / aySMUkane    SMUkanä / SMUkane
+topical     TSMUkanìri             meSMUkanìri              pxeSMUkanìri               aySMUkanìri              SMUkanìri
+`, stdout)
+
+	code, stdout, _ = runCommand(t, "", "decline", "-mark", "upper", "'u: thing")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "short plural\n")
+	assert.Contains(t, stdout, "agentive    'UL         meUL")
+	assert.Contains(t, stdout, "genitive    'Uä / 'Ue   meUä / meUe")
+
+	code, _, stderr := runCommand(t, "", "decline")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "Usage: litxap decline")
+
+	code, _, stderr = runCommand(t, "", "decline", "-mark", "", "tu.te")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "the mark can not be empty")
+}
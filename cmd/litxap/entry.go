@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+func runEntry(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap entry", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: litxap entry NOTATION [WORD]
+
+Shows how the syllables of an entry are generated, e.g. "t·ì.*r·an: tì- <us> -ìri", and how they match the word
+if one is given. It exits with 3 if the word does not match.`)
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		return exitError
+	}
+
+	entry := litxap.ParseEntry(flags.Arg(0))
+	stages, offset, err := entry.GenerateStagesE()
+	if err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+	last := stages[len(stages)-1]
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "entry\t%s\n", entry.String())
+	for _, stage := range stages {
+		fmt.Fprintf(tw, "%s\t%s%s", stage.Name, litxap.DotSyllables(stage.Syllables, stage.Stress), stageAffixes(entry, stage.Name))
+		if len(stage.Lenitions) > 0 {
+			fmt.Fprintf(tw, " (lenition %s)", strings.Join(stage.Lenitions, ", "))
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "stress\t%d\n", last.Stress)
+	fmt.Fprintf(tw, "offset\t%d\n", offset)
+
+	if flags.NArg() < 2 {
+		tw.Flush()
+		return exitOK
+	}
+
+	word := flags.Arg(1)
+	trace := litxaputil.TraceMatch(word, last.Syllables, offset, last.Stress)
+
+	fmt.Fprintf(tw, "word\t%s\n", word)
+	for _, step := range trace.Steps {
+		fmt.Fprintf(tw, "\t%s\t= %s\n", strings.Join(step.Expected, "."), strings.Join(step.Matched, "."))
+	}
+
+	pass := ""
+	if trace.Fused {
+		pass = " (with contractions)"
+	}
+
+	if trace.Syllables == nil {
+		fmt.Fprintf(tw, "match\tfailed%s at %q, expected %s\n", pass, trace.Rest, strings.Join(trace.Remaining, "."))
+		tw.Flush()
+		return exitUnknownWords
+	}
+
+	fmt.Fprintf(tw, "match\t%s%s\n", litxap.DotSyllables(trace.Syllables, trace.Stress), pass)
+	tw.Flush()
+
+	return exitOK
+}
+
+// stageAffixes lists the affixes applied at the stage in the notation of Entry.String, without stress marks, in a
+// column of its own.
+func stageAffixes(entry *litxap.Entry, stage string) string {
+	switch stage {
+	case "prefixes":
+		return "\t" + strings.Join(litxaputil.AffixNames(entry.Prefixes), "-") + "-"
+	case "infixes":
+		return "\t<" + strings.Join(entry.Infixes, ",") + ">"
+	case "suffixes":
+		return "\t-" + strings.Join(litxaputil.AffixNames(entry.Suffixes), "-")
+	}
+
+	return ""
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunEntry(t *testing.T) {
+	code, stdout, _ := runCommand(t, "", "entry", "t·a.r·on: tì- <us> -ti: hunt", "Tìtusaronti")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, `entry     t·a.r·on: tì- <us> -ti: hunt
+root      ta.ron
+prefixes  tì.*ta.ron        tì-
+infixes   tì.tu.*sa.ron     <us>
+suffixes  tì.tu.*sa.ron.ti  -ti
+stress    2
+offset    1
+word      Tìtusaronti
+          tì   = Tì
+          tu   = tu
+          sa   = sa
+          ron  = ron
+          ti   = ti
+match     Tì.tu.*sa.ron.ti
+`, stdout)
+
+	code, stdout, _ = runCommand(t, "", "entry", "fme.tok: -yu", "fmetokari")
+	assert.Equal(t, exitUnknownWords, code)
+	assert.Contains(t, stdout, "match     failed at \"ari\", expected yu\n")
+
+	code, stdout, _ = runCommand(t, "", "entry", "ak.ka", "aka")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "match   a.ka (with contractions)\n")
+
+	code, stdout, _ = runCommand(t, "", "entry", "tu.te: ay-", "aysute")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "prefixes  ay.*su.te  ay- (lenition t→s)\n")
+
+	code, stdout, _ = runCommand(t, "", "entry", "tseng: *tsa- -ri>")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "prefixes  tsa.tseng      tsa-\n")
+	assert.Contains(t, stdout, "suffixes  tsa.*tseng.ri  -ri\n")
+
+	code, stdout, _ = runCommand(t, "", "entry", "kal.*txì")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "entry   kal.*txì\nroot    kal.*txì\nstress  1\noffset  0\n", stdout)
+
+	code, stdout, stderr := runCommand(t, "", "entry", "t·a.r·on: <glurb>")
+	assert.Equal(t, exitError, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "litxap: infix \"glurb\": unknown affix\n", stderr)
+
+	code, _, stderr = runCommand(t, "", "entry")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "Usage: litxap entry")
+}
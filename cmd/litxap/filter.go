@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gissleh/litxap"
+)
+
+func runFilter(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap filter", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: litxap filter -dict FILE [-mark accent|upper|TEXT] [FILE...]
+
+Copies the text through as it is, except that the stressed syllables of the words found in the dictionary are
+marked. Unknown words are left as they are. If the dictionary fails on a line, that line is copied unchanged and
+the exit code is 2.`)
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	mark := flags.String("mark", "accent", `"accent" for an acute accent, "upper" for uppercase, or text to put in front of the syllable`)
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	format := markFormatter(*mark)
+	if format == nil {
+		printError(stderr, errors.New("the mark can not be empty"))
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	code := exitOK
+	lineNo := 0
+	err = eachLineEnding(flags.Args(), stdin, func(s, ending string) error {
+		lineNo++
+
+		out, _, err := litxap.FormatLine(s, dict, format)
+		if err != nil {
+			fmt.Fprintf(stderr, "line %d: %v\n", lineNo, err)
+			code = exitDictionary
+			out = s
+		}
+
+		// Written line by line, so that the output keeps up when used in a pipeline.
+		_, err = io.WriteString(stdout, out+ending)
+		return err
+	})
+	if err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+
+	return code
+}
+
+func markFormatter(mark string) litxap.WordFormatter {
+	switch mark {
+	case "":
+		return nil
+	case "accent":
+		return litxap.AccentStress
+	case "upper":
+		return litxap.UpperStress
+	default:
+		return litxap.WrapStress(mark, "")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunFilter(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	table := []struct {
+		mark   string
+		input  string
+		output string
+	}{
+		{"accent", "Kaltxì, ma fmetokyu!\n", "Kaltxì\u0301, má fmétokyu!\n"},
+		{"upper", "Kaltxì, ma fmetokyu!\r\n", "KalTXÌ, MA FMEtokyu!\r\n"},
+		{"ˈ", "  «Ma» -- hello,\ttsmukan!?\n\nkameie", "  «ˈMa» -- hello,\ttsmukan!?\n\nkameie"},
+		{"*", "“Ma”, kaltxì|Kaltxì\n", "“*Ma”, kaltxì|Kal*txì\n"},
+	}
+
+	for _, row := range table {
+		t.Run(row.mark, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, row.input, "filter", "-dict", dict, "-mark", row.mark)
+			assert.Equal(t, exitOK, code)
+			assert.Equal(t, row.output, stdout)
+			assert.Empty(t, stderr)
+		})
+	}
+}
+
+func TestRunFilter_InvalidUTF8(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	code, stdout, stderr := runCommand(t, "Kaltx\xff ma\n", "filter", "-dict", dict, "-mark", "upper")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "Kaltx\xff MA\n", stdout)
+	assert.Empty(t, stderr)
+}
+
+func TestRunFilter_Files(t *testing.T) {
+	dict := writeTestDictionary(t)
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("ma\r\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("oel\n"), 0644))
+
+	code, stdout, _ := runCommand(t, "", "filter", "-dict", dict, "-mark", "upper", filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"))
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "MA\r\nOel\n", stdout)
+
+	code, _, stderr := runCommand(t, "", "filter", "-dict", dict, "-mark", "")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "mark")
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gissleh/litxap/lsp"
+)
+
+func runLSP(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap lsp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: litxap lsp -dict FILE")
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	// stdout belongs to the protocol, so nothing else may be written there.
+	if err := lsp.New(dict).Serve(stdin, stdout); err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+
+	return exitOK
+}
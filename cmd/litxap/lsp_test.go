@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLSP(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	input := ""
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///a.txt","text":"ma tsmukan"}}}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	} {
+		input += fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+
+	code, stdout, stderr := runCommand(t, input, "lsp", "-dict", dict)
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, 2, strings.Count(stdout, "Content-Length:"))
+	assert.Contains(t, stdout, `"hoverProvider":true`)
+	assert.Contains(t, stdout, `"message":"unknown word: tsmukan"`)
+
+	code, _, _ = runCommand(t, "Content-Type: x\r\n\r\n", "lsp", "-dict", dict)
+	assert.Equal(t, exitError, code)
+}
@@ -0,0 +1,158 @@
+// Command litxap finds the stressed syllables of Na'vi words in text, using a dictionary file.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitDictionary   = 2
+	exitUnknownWords = 3
+	exitChanged      = 4
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// A command is a subcommand, e.g. "litxap serve". It returns the exit code.
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"serve":   runServe,
+	"repl":    runREPL,
+	"lsp":     runLSP,
+	"entry":   runEntry,
+	"filter":  runFilter,
+	"report":  runReport,
+	"check":   runCheck,
+	"cards":   runCards,
+	"decline": runDecline,
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd(args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	return runAnalyze(args, stdin, stdout, stderr)
+}
+
+// dictFlags collects the -dict flags, which can be given more than once.
+type dictFlags []string
+
+func (d *dictFlags) String() string {
+	return strings.Join(*d, ",")
+}
+
+func (d *dictFlags) Set(value string) error {
+	*d = append(*d, value)
+	return nil
+}
+
+func (d *dictFlags) load() (litxap.Dictionary, error) {
+	if len(*d) == 0 {
+		return nil, errNoDictionary
+	}
+
+	dicts := make(litxap.MultiDictionary, 0, len(*d))
+	for _, path := range *d {
+		dict, err := litxap.LoadDictionary(path)
+		if err != nil {
+			return nil, err
+		}
+
+		dicts = append(dicts, dict)
+	}
+
+	if len(dicts) == 1 {
+		return dicts[0], nil
+	}
+
+	return dicts, nil
+}
+
+// entries lists every entry in the dictionaries, in the order the files were given.
+func (d *dictFlags) entries() ([]litxap.Entry, error) {
+	if len(*d) == 0 {
+		return nil, errNoDictionary
+	}
+
+	var res []litxap.Entry
+	for _, path := range *d {
+		dict, err := litxap.LoadDictionary(path)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := dict.ListEntries()
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, entries...)
+	}
+
+	return res, nil
+}
+
+// eachLine calls fn with every line in the files, or stdin if there are none. The line endings are not included.
+func eachLine(files []string, stdin io.Reader, fn func(line string) error) error {
+	return eachLineEnding(files, stdin, func(line, _ string) error {
+		return fn(line)
+	})
+}
+
+// eachLineEnding is eachLine, but the line ending ("\n", "\r\n" or "" at the end of a file) is given to fn as well.
+func eachLineEnding(files []string, stdin io.Reader, fn func(line, ending string) error) error {
+	readers := []io.Reader{stdin}
+	if len(files) > 0 {
+		readers = readers[:0]
+		for _, name := range files {
+			file, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			readers = append(readers, file)
+		}
+	}
+
+	for _, reader := range readers {
+		br := bufio.NewReader(reader)
+		for {
+			line, err := br.ReadString('\n')
+			if len(line) > 0 {
+				text := strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+				if err := fn(text, line[len(text):]); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func printError(stderr io.Writer, err error) {
+	fmt.Fprintln(stderr, "litxap:", err)
+}
+
+var errNoDictionary = errors.New("no dictionary given, use -dict")
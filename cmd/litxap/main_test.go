@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDictionary = `kal.*txì: : hello
+ma: : oh
+fme.tok: -yu: tester
+Oel	o.e: -l: I
+ngati	nga: -ti: you
+kameie	k·a.m·e: <ei>: see
+kameie	k··ä: <am,ei>: go
+`
+
+func writeTestDictionary(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "dict.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(testDictionary), 0644))
+
+	return path
+}
+
+func runCommand(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestDictFlags(t *testing.T) {
+	var dicts dictFlags
+	assert.NoError(t, dicts.Set("a.txt"))
+	assert.NoError(t, dicts.Set("b.json"))
+	assert.Equal(t, "a.txt,b.json", dicts.String())
+
+	dict, err := (&dictFlags{}).load()
+	assert.ErrorIs(t, err, errNoDictionary)
+	assert.Nil(t, dict)
+}
+
+func TestEachLine(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\r\nb\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("c"), 0644))
+
+	var lines []string
+	err := eachLine([]string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}, nil, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, lines)
+
+	lines = nil
+	err = eachLine(nil, strings.NewReader("d\n\ne\n"), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d", "", "e"}, lines)
+
+	err = eachLine([]string{filepath.Join(dir, "missing.txt")}, nil, func(string) error { return nil })
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
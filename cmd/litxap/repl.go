@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gissleh/litxap"
+)
+
+const replHelp = `Type a line of Na'vi to analyse it. When a word has several readings, pick one by pressing its key, or
+Ctrl-C to leave the line as it is.
+Commands:
+  :lookup WORD      list the dictionary entries for WORD and the syllables they generate
+  :entry NOTATION   show the entry parsed from NOTATION, e.g. "t·ì.*r·an: tì- <us> -ìri"
+  :choices          list the choices made this session
+  :forget [WORD]    forget the choice for WORD, or all choices
+  :export [FILE]    write the resolved text so far to FILE, or print it
+  :help             show this help
+  :quit             leave
+`
+
+func runREPL(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap repl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: litxap repl -dict FILE")
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	repl := &repl{
+		dict:    dict,
+		in:      bufio.NewReader(stdin),
+		out:     stdout,
+		choices: make(map[string]string),
+	}
+	if f, ok := stdin.(*os.File); ok {
+		repl.rawKeys = func() func() { return rawKeys(f) }
+	}
+
+	if err := repl.loop(); err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	return exitOK
+}
+
+// repl is an interactive session. The choices map a lowercase word to the notation of the entry that was picked.
+type repl struct {
+	dict     litxap.Dictionary
+	in       *bufio.Reader
+	out      io.Writer
+	choices  map[string]string
+	resolved []string
+	// rawKeys switches the terminal to single keys while a reading is picked, see rawKeys. It's nil if the input
+	// isn't a file, and then the key is read as a line.
+	rawKeys func() func()
+}
+
+func (r *repl) loop() error {
+	fmt.Fprintln(r.out, "litxap repl, type :help for help.")
+
+	for {
+		input, ok := r.prompt("> ")
+		if !ok {
+			return nil
+		}
+
+		command, arg, _ := strings.Cut(strings.TrimSpace(input), " ")
+		arg = strings.TrimSpace(arg)
+
+		switch command {
+		case "":
+			continue
+		case ":quit", ":q":
+			return nil
+		case ":help":
+			fmt.Fprint(r.out, replHelp)
+		case ":lookup":
+			if err := r.lookup(arg); err != nil {
+				return err
+			}
+		case ":entry":
+			r.showEntry(litxap.ParseEntry(arg))
+		case ":choices":
+			words := make([]string, 0, len(r.choices))
+			for word := range r.choices {
+				words = append(words, word)
+			}
+			slices.Sort(words)
+
+			for _, word := range words {
+				fmt.Fprintf(r.out, "%s: %s\n", word, r.choices[word])
+			}
+		case ":forget":
+			if arg == "" {
+				clear(r.choices)
+			} else {
+				delete(r.choices, strings.ToLower(arg))
+			}
+		case ":export":
+			if err := r.export(arg); err != nil {
+				fmt.Fprintln(r.out, "error:", err)
+			}
+		default:
+			if strings.HasPrefix(command, ":") {
+				fmt.Fprintf(r.out, "unknown command %s, type :help for help\n", command)
+				continue
+			}
+
+			if err := r.analyse(input); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *repl) analyse(input string) error {
+	line, err := litxap.RunLine(input, r.dict)
+	if err != nil {
+		return err
+	}
+
+	for i, part := range line {
+		if !part.IsWord {
+			continue
+		}
+		if len(part.Matches) == 0 {
+			fmt.Fprintf(r.out, "%s: not found\n", part.Raw)
+			continue
+		}
+		if _, stress := part.Syllables(); stress >= 0 {
+			continue
+		}
+
+		choice, ok := r.choose(part)
+		if !ok {
+			return nil
+		}
+
+		line[i].Matches = []litxap.LinePartMatch{choice}
+	}
+
+	resolved := line.Format(input, litxap.AccentStress)
+	r.resolved = append(r.resolved, resolved)
+	fmt.Fprintln(r.out, resolved)
+
+	return nil
+}
+
+// choose picks one of the part's matches, either from an earlier choice or by asking. It only fails on EOF.
+func (r *repl) choose(part litxap.LinePart) (litxap.LinePartMatch, bool) {
+	word := strings.ToLower(part.Raw)
+	if notation, ok := r.choices[word]; ok {
+		for _, match := range part.Matches {
+			if match.Entry.String() == notation {
+				return match, true
+			}
+		}
+	}
+
+	keys := pickKeys[:min(len(part.Matches), len(pickKeys))]
+	fmt.Fprintf(r.out, "%s has %d readings:\n", part.Raw, len(part.Matches))
+	for i, match := range part.Matches[:len(keys)] {
+		fmt.Fprintf(r.out, "  %c) %s\t%s\n", keys[i], litxap.DotSyllables(match.Syllables, match.Stress), match.Entry.String())
+	}
+	if len(part.Matches) > len(keys) {
+		fmt.Fprintf(r.out, "  and %d more that can't be picked\n", len(part.Matches)-len(keys))
+	}
+
+	var restore func()
+	if r.rawKeys != nil {
+		restore = r.rawKeys()
+	}
+	if restore != nil {
+		defer restore()
+	}
+
+	prompt := fmt.Sprintf("pick %c-%c: ", keys[0], keys[len(keys)-1])
+	fmt.Fprint(r.out, prompt)
+	for {
+		key, ok := r.readKey(restore != nil)
+		if !ok {
+			fmt.Fprintln(r.out)
+			return litxap.LinePartMatch{}, false
+		}
+
+		n := slices.Index(keys, key)
+		if n < 0 {
+			// A key press that doesn't pick anything is ignored, but a line has to be asked for again.
+			if restore == nil {
+				fmt.Fprint(r.out, prompt)
+			}
+			continue
+		}
+		if restore != nil {
+			fmt.Fprintf(r.out, "%c\n", key)
+		}
+
+		match := part.Matches[n]
+		r.choices[word] = match.Entry.String()
+
+		return match, true
+	}
+}
+
+// pickKeys are the keys for picking a reading, in the order they're given out.
+var pickKeys = []rune("123456789abcdefghijklmnopqrstuvwxyz")
+
+// readKey reads the key for a pick, which is a single key press in raw mode, and otherwise a line with nothing but
+// the key on it. It fails on EOF, and on Ctrl-C or Ctrl-D in raw mode.
+func (r *repl) readKey(raw bool) (rune, bool) {
+	if raw {
+		ch, _, err := r.in.ReadRune()
+		if err != nil || ch == 0x03 || ch == 0x04 {
+			return 0, false
+		}
+
+		return ch, true
+	}
+
+	line, err := r.in.ReadString('\n')
+	if err != nil && line == "" {
+		return 0, false
+	}
+
+	key := []rune(strings.TrimSpace(line))
+	if len(key) != 1 {
+		return utf8.RuneError, true
+	}
+
+	return key[0], true
+}
+
+func (r *repl) lookup(word string) error {
+	entries, err := r.dict.LookupEntries(word)
+	if errors.Is(err, litxap.ErrEntryNotFound) {
+		fmt.Fprintf(r.out, "%s: not found\n", word)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		r.showEntry(&entry)
+
+		syllables, stress, err := litxap.RunWordE(word, entry)
+		if err != nil {
+			continue
+		}
+		if syllables != nil && stress >= 0 {
+			fmt.Fprintf(r.out, "  matched:   %s\n", litxap.DotSyllables(syllables, stress))
+		} else {
+			fmt.Fprintln(r.out, "  matched:   no")
+		}
+	}
+
+	return nil
+}
+
+func (r *repl) showEntry(entry *litxap.Entry) {
+	fmt.Fprintln(r.out, entry.String())
+
+	syllables, stress, root, err := entry.GenerateSyllablesE()
+	if err != nil {
+		fmt.Fprintf(r.out, "  error:     %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(r.out, "  generated: %s (stress %d, root %d)\n", litxap.DotSyllables(syllables, stress), stress, root)
+}
+
+func (r *repl) export(path string) error {
+	text := strings.Join(r.resolved, "\n") + "\n"
+	if path == "" {
+		_, err := io.WriteString(r.out, text)
+		return err
+	}
+
+	return os.WriteFile(path, []byte(text), 0644)
+}
+
+func (r *repl) prompt(prompt string) (string, bool) {
+	fmt.Fprint(r.out, prompt)
+
+	line, err := r.in.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(r.out)
+		return "", false
+	}
+
+	return strings.TrimRight(line, "\r\n"), true
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunREPL(t *testing.T) {
+	dict := writeTestDictionary(t)
+	export := filepath.Join(t.TempDir(), "export.txt")
+
+	input := strings.Join([]string{
+		"Kaltxì, ma fmetokyu!",
+		"oel kameie",
+		"3",
+		"2",
+		"Kameie tsmukan.",
+		":choices",
+		":forget kameie",
+		":choices",
+		":export " + export,
+		":nope",
+		":quit",
+		"ma",
+	}, "\n") + "\n"
+
+	code, stdout, stderr := runCommand(t, input, "repl", "-dict", dict)
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+
+	assert.Contains(t, stdout, "> Kaltxì\u0301, má fmétokyu!\n")
+	assert.Contains(t, stdout, "kameie has 2 readings:\n")
+	assert.Contains(t, stdout, "  1) ka.me.i.e\tk·a.m·e: <ei>: see\n")
+	assert.Contains(t, stdout, "pick 1-2: pick 1-2: óel kameié\n")
+	assert.Contains(t, stdout, "tsmukan: not found\n")
+	assert.Contains(t, stdout, "Kameié tsmukan.\n")
+	assert.Contains(t, stdout, "kameie: k··ä: <am,ei>: go\n")
+	assert.NotContains(t, stdout, "má\n")
+
+	data, err := os.ReadFile(export)
+	assert.NoError(t, err)
+	assert.Equal(t, "Kaltxì\u0301, má fmétokyu!\nóel kameié\nKameié tsmukan.\n", string(data))
+	assert.Equal(t, 1, strings.Count(stdout, "readings:"))
+	assert.Contains(t, stdout, "unknown command :nope")
+}
+
+func TestRunREPL_Inspect(t *testing.T) {
+	dict := writeTestDictionary(t)
+
+	input := ":entry fme.tok: -yu: tester\n:lookup fmetokyu\n:lookup tsmukan\n:lookup oel\n:entry ta.ron: fnetsa-\n"
+	code, stdout, _ := runCommand(t, input, "repl", "-dict", dict)
+	assert.Equal(t, exitOK, code)
+
+	assert.Contains(t, stdout, "> fme.tok: -yu: tester\n  generated: fme.tok.yu (stress 0, root 0)\n")
+	assert.Contains(t, stdout, "  matched:   fme.tok.yu\n")
+	assert.Contains(t, stdout, "tsmukan: not found\n")
+	assert.Contains(t, stdout, "o.e: -l: I\n  generated: o.el (stress 0, root 0)\n  matched:   o.el\n")
+	assert.Contains(t, stdout, "ta.ron: fnetsa-\n  error:     prefix \"fnetsa\": unknown affix\n")
+
+	code, _, stderr := runCommand(t, "", "repl")
+	assert.Equal(t, exitDictionary, code)
+	assert.Contains(t, stderr, "dictionary")
+}
+
+func TestREPL_RawKeys(t *testing.T) {
+	dict, err := litxap.LoadDictionary(writeTestDictionary(t))
+	assert.NoError(t, err)
+
+	table := []struct {
+		keys     string
+		expected string
+	}{
+		{"x2", "pick 1-2: 2\nóel kameié\n"},
+		{"1", "pick 1-2: 1\nóel kámeie\n"},
+		{"\x03", "pick 1-2: \n"},
+		{"", "pick 1-2: \n"},
+	}
+
+	for _, row := range table {
+		t.Run(row.expected, func(t *testing.T) {
+			out := bytes.Buffer{}
+			restored := 0
+			r := &repl{
+				dict:    dict,
+				in:      bufio.NewReader(strings.NewReader(row.keys)),
+				out:     &out,
+				choices: make(map[string]string),
+				rawKeys: func() func() { return func() { restored++ } },
+			}
+
+			assert.NoError(t, r.analyse("oel kameie"))
+			assert.True(t, strings.HasSuffix(out.String(), row.expected), out.String())
+			assert.Equal(t, 1, restored)
+		})
+	}
+}
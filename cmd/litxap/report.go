@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+func runReport(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap report", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: litxap report -dict FILE [-format text|csv] [-samples N] [FILE...]
+
+Runs every line and lists the words that were not found, the words that were found but did not match any of their
+entries, and the words whose matches disagree on the syllables or on the stress. Line numbers count on across the files.`)
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	format := flags.String("format", "text", "output format: text or csv")
+	samples := flags.Int("samples", 3, "how many sample lines to give for each word")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if *format != "text" && *format != "csv" {
+		printError(stderr, fmt.Errorf("unknown format %q", *format))
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	report := newReport(dict, *samples)
+	lineNo := 0
+	err = eachLine(flags.Args(), stdin, func(s string) error {
+		lineNo++
+		return report.add(lineNo, s)
+	})
+	if err != nil {
+		printError(stderr, err)
+
+		if errors.As(err, new(*dictionaryError)) {
+			return exitDictionary
+		}
+
+		return exitError
+	}
+
+	if *format == "csv" {
+		err = report.writeCSV(stdout)
+	} else {
+		err = report.writeText(stdout)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// The categories of the report, in the order they're written.
+const (
+	categoryUnknown   = "unknown"
+	categoryRejected  = "rejected"
+	categorySyllables = "syllables"
+	categoryStress    = "stress"
+)
+
+var reportCategories = []string{categoryUnknown, categoryRejected, categorySyllables, categoryStress}
+
+var categoryTitles = map[string]string{
+	categoryUnknown:   "Not in the dictionary",
+	categoryRejected:  "In the dictionary, but no entry matched",
+	categorySyllables: "Matches disagree on the syllables",
+	categoryStress:    "Matches disagree on the stress",
+}
+
+type report struct {
+	dict       litxap.Dictionary
+	maxSamples int
+	words      map[string]map[string]*reportWord
+}
+
+// reportWord is a word in one of the categories. The details are the entries that were rejected and why, or the
+// readings that disagree.
+type reportWord struct {
+	word    string
+	count   int
+	details []string
+	samples []reportSample
+}
+
+type reportSample struct {
+	lineNo int
+	text   string
+}
+
+func newReport(dict litxap.Dictionary, maxSamples int) *report {
+	r := &report{dict: dict, maxSamples: maxSamples, words: make(map[string]map[string]*reportWord)}
+	for _, category := range reportCategories {
+		r.words[category] = make(map[string]*reportWord)
+	}
+
+	return r
+}
+
+func (r *report) add(lineNo int, s string) error {
+	line, err := litxap.RunLine(s, r.dict)
+	if err != nil {
+		return &dictionaryError{err: err}
+	}
+
+	for _, part := range line {
+		if !part.IsWord {
+			continue
+		}
+
+		word := strings.ToLower(part.Raw)
+		if part.Lookup != "" {
+			word = strings.ToLower(part.Lookup) + "|" + word
+		}
+
+		if len(part.Matches) == 0 {
+			if len(part.Errors) == 0 {
+				r.record(categoryUnknown, word, lineNo, s, nil)
+				continue
+			}
+
+			details := make([]string, 0, len(part.Errors))
+			for _, matchErr := range part.Errors {
+				details = append(details, fmt.Sprintf("%s (%s)", matchErr.Entry.String(), matchErr.Message))
+			}
+
+			r.record(categoryRejected, word, lineNo, s, details)
+			continue
+		}
+
+		syllables, stress := part.Syllables()
+		if syllables != nil && stress >= 0 {
+			continue
+		}
+
+		details := make([]string, 0, len(part.Matches))
+		for _, match := range part.Matches {
+			details = append(details, litxap.DotSyllables(match.Syllables, match.Stress))
+		}
+
+		if syllables == nil {
+			r.record(categorySyllables, word, lineNo, s, details)
+		} else {
+			r.record(categoryStress, word, lineNo, s, details)
+		}
+	}
+
+	return nil
+}
+
+func (r *report) record(category, word string, lineNo int, text string, details []string) {
+	rw := r.words[category][word]
+	if rw == nil {
+		rw = &reportWord{word: word}
+		r.words[category][word] = rw
+	}
+
+	rw.count++
+	for _, detail := range details {
+		if !slices.Contains(rw.details, detail) {
+			rw.details = append(rw.details, detail)
+		}
+	}
+
+	// A word that's in the same line twice only needs one sample of it.
+	if len(rw.samples) < r.maxSamples && (len(rw.samples) == 0 || rw.samples[len(rw.samples)-1].lineNo != lineNo) {
+		rw.samples = append(rw.samples, reportSample{lineNo: lineNo, text: text})
+	}
+}
+
+// sorted lists the words in the category, the most common first.
+func (r *report) sorted(category string) []*reportWord {
+	res := make([]*reportWord, 0, len(r.words[category]))
+	for _, rw := range r.words[category] {
+		res = append(res, rw)
+	}
+
+	slices.SortFunc(res, func(a, b *reportWord) int {
+		if a.count != b.count {
+			return b.count - a.count
+		}
+
+		return strings.Compare(a.word, b.word)
+	})
+
+	return res
+}
+
+func (r *report) writeText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for i, category := range reportCategories {
+		words := r.sorted(category)
+		total := 0
+		for _, rw := range words {
+			total += rw.count
+		}
+
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		fmt.Fprintf(bw, "%s: %d words, %d occurrences\n", categoryTitles[category], len(words), total)
+
+		for _, rw := range words {
+			fmt.Fprintf(bw, "  %s (%d)", rw.word, rw.count)
+			if len(rw.details) > 0 {
+				fmt.Fprintf(bw, ": %s", strings.Join(rw.details, "; "))
+			}
+			bw.WriteString("\n")
+
+			for _, sample := range rw.samples {
+				fmt.Fprintf(bw, "    %d: %s\n", sample.lineNo, sample.text)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (r *report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"category", "word", "count", "details", "lines", "samples"}); err != nil {
+		return err
+	}
+
+	for _, category := range reportCategories {
+		for _, rw := range r.sorted(category) {
+			lines := make([]string, 0, len(rw.samples))
+			texts := make([]string, 0, len(rw.samples))
+			for _, sample := range rw.samples {
+				lines = append(lines, strconv.Itoa(sample.lineNo))
+				texts = append(texts, sample.text)
+			}
+
+			err := cw.Write([]string{
+				category,
+				rw.word,
+				strconv.Itoa(rw.count),
+				strings.Join(rw.details, "; "),
+				strings.Join(lines, " "),
+				strings.Join(texts, "\n"),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
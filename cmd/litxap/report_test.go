@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/stretchr/testify/assert"
+)
+
+const reportInput = `Kaltxì, ma tsmukan!
+Oel kameie tsmukan, tsmukan.
+fmetokari kameie
+oel|Oeli sìltsan
+tsaleu
+`
+
+// writeReportDictionary writes the test dictionary with a word that has two syllable splits.
+func writeReportDictionary(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "report.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(testDictionary+"\ntsa.leu: : that\ntsa.le.u: : that\n"), 0644))
+
+	return path
+}
+
+func TestRunReport(t *testing.T) {
+	dict := writeReportDictionary(t)
+
+	code, stdout, stderr := runCommand(t, reportInput, "report", "-dict", dict, "-samples", "1")
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, `Not in the dictionary: 3 words, 5 occurrences
+  tsmukan (3)
+    1: Kaltxì, ma tsmukan!
+  fmetokari (1)
+    3: fmetokari kameie
+  sìltsan (1)
+    4: oel|Oeli sìltsan
+
+In the dictionary, but no entry matched: 1 words, 1 occurrences
+  oel|oeli (1): o.e: -l: I (word "Oeli": does not match the entry)
+    4: oel|Oeli sìltsan
+
+Matches disagree on the syllables: 1 words, 1 occurrences
+  tsaleu (1): tsa.leu; tsa.le.u
+    5: tsaleu
+
+Matches disagree on the stress: 1 words, 2 occurrences
+  kameie (2): ka.me.i.e; ka.me.i.*e
+    2: Oel kameie tsmukan, tsmukan.
+`, stdout)
+}
+
+func TestRunReport_CSV(t *testing.T) {
+	dict := writeReportDictionary(t)
+
+	code, stdout, _ := runCommand(t, reportInput, "report", "-dict", dict, "-format", "csv")
+	assert.Equal(t, exitOK, code)
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"category", "word", "count", "details", "lines", "samples"}, records[0])
+	assert.Equal(t, []string{"unknown", "tsmukan", "3", "", "1 2", "Kaltxì, ma tsmukan!\nOel kameie tsmukan, tsmukan."}, records[1])
+	assert.Equal(t, []string{"syllables", "tsaleu", "1", "tsa.leu; tsa.le.u", "5", "tsaleu"}, records[5])
+	assert.Equal(t, []string{"stress", "kameie", "2", "ka.me.i.e; ka.me.i.*e", "2 3", "Oel kameie tsmukan, tsmukan.\nfmetokari kameie"}, records[6])
+	assert.Len(t, records, 7)
+
+	code, _, _ = runCommand(t, "", "report", "-dict", dict, "-format", "xml")
+	assert.Equal(t, exitError, code)
+}
+
+func TestReport_BrokenEntry(t *testing.T) {
+	broken := litxap.Entry{Word: "txon", Syllables: []string{"txon"}, Suffixes: []string{"teriri"}}
+	r := newReport(litxap.MapDictionary{"txon": {broken}}, 3)
+
+	assert.NoError(t, r.add(1, "txon"))
+	assert.Equal(t, []string{`txon: -teriri (suffix "teriri": unknown affix)`}, r.words[categoryRejected]["txon"].details)
+	assert.Empty(t, r.words[categoryUnknown])
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gissleh/litxap/server"
+)
+
+func runServe(args []string, _ io.Reader, _, stderr io.Writer) int {
+	flags := flag.NewFlagSet("litxap serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: litxap serve -dict FILE [-addr :8080]")
+		flags.PrintDefaults()
+	}
+
+	var dicts dictFlags
+	flags.Var(&dicts, "dict", "dictionary file (.txt, .tsv or .json), can be given more than once")
+	addr := flags.String("addr", ":8080", "address to listen on")
+	maxBody := flags.Int64("max-body", 1<<20, "largest request body in bytes")
+	maxLines := flags.Int("max-lines", 1000, "most lines in one /lines request")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	dict, err := dicts.load()
+	if err != nil {
+		printError(stderr, err)
+		return exitDictionary
+	}
+
+	handler := server.New(dict)
+	handler.MaxBodyBytes = *maxBody
+	handler.MaxLines = *maxLines
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintln(stderr, "litxap: listening on", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		printError(stderr, err)
+		return exitError
+	}
+
+	return exitOK
+}
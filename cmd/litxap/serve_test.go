@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunServe_Fail(t *testing.T) {
+	code, _, stderr := runCommand(t, "", "serve")
+	assert.Equal(t, exitDictionary, code)
+	assert.Contains(t, stderr, "no dictionary")
+
+	code, _, _ = runCommand(t, "", "serve", "-nope")
+	assert.Equal(t, exitError, code)
+
+	code, _, stderr = runCommand(t, "", "serve", "-dict", writeTestDictionary(t), "-addr", "256.0.0.1:-1")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "listening on")
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// rawKeys switches the terminal f to giving each key as it's pressed, without echoing it or waiting for Enter, and
+// returns a function that switches it back. It returns nil if f is not a terminal, or if stty can't change it, as on
+// systems without stty.
+func rawKeys(f *os.File) func() {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+
+	saved, err := stty(f, "-g")
+	if err != nil {
+		return nil
+	}
+	if _, err := stty(f, "-icanon", "-echo", "-isig", "min", "1"); err != nil {
+		return nil
+	}
+
+	return func() {
+		_, _ = stty(f, strings.TrimSpace(saved))
+	}
+}
+
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawKeys_NotTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "input.txt"))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.Nil(t, rawKeys(f))
+}
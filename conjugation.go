@@ -0,0 +1,78 @@
+package litxap
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// Conjugation is a verb with a set of infixes.
+type Conjugation struct {
+	// Infixes are the infixes in slot order, e.g. "äp", "ol", "ei". It's empty for the bare verb.
+	Infixes []string `json:"infixes"`
+	// Entry is the entry with its Infixes replaced by the ones above.
+	Entry     Entry    `json:"entry"`
+	Word      string   `json:"word"`
+	Syllables []string `json:"syllables"`
+	Stress    int      `json:"stress"`
+}
+
+// Conjugate puts the infixes in the verb, in place of any it already has. Unknown infixes, and more than one infix
+// in the same slot, are errors, except for äp and eyk together.
+func Conjugate(entry Entry, infixes []string) (Conjugation, error) {
+	if entry.InfixPos == nil {
+		return Conjugation{}, &litxaputil.AffixError{Kind: "infixPos", Err: litxaputil.ErrMissingField}
+	}
+	if err := litxaputil.ValidateInfixes(infixes); err != nil {
+		return Conjugation{}, err
+	}
+
+	entry.Infixes = append([]string{}, infixes...)
+	syllables, stress, _, err := entry.GenerateSyllablesE()
+	if err != nil {
+		return Conjugation{}, err
+	}
+
+	return Conjugation{
+		Infixes:   entry.Infixes,
+		Entry:     entry,
+		Word:      strings.Join(syllables, ""),
+		Syllables: syllables,
+		Stress:    stress,
+	}, nil
+}
+
+// Conjugations lists the verb with every combination of at most one infix from each slot, from the bare verb to
+// äpeyk, awn and ats together, in the order of litxaputil.VerbInfixes. Combinations that clash, like a participle
+// with the affect of slot 2, are left out.
+func Conjugations(entry Entry) ([]Conjugation, error) {
+	combinations := [][]string{{}}
+	for slot := 0; slot < 3; slot++ {
+		next := make([][]string, 0, len(combinations)*(len(litxaputil.VerbInfixes(slot))+1))
+		for _, combination := range combinations {
+			next = append(next, combination)
+			for _, infix := range litxaputil.VerbInfixes(slot) {
+				next = append(next, append(combination[:len(combination):len(combination)], infix))
+			}
+		}
+
+		combinations = next
+	}
+
+	res := make([]Conjugation, 0, len(combinations))
+	for _, infixes := range combinations {
+		if errors.Is(litxaputil.ValidateInfixes(infixes), litxaputil.ErrInfixClash) {
+			continue
+		}
+
+		conjugation, err := Conjugate(entry, infixes)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, conjugation)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package litxap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConjugate(t *testing.T) {
+	table := []struct {
+		entry    string
+		infixes  string
+		expected string
+		err      string
+	}{
+		{"t·a.r·on: hunt", "", "ta.ron", ""},
+		{"t·a.r·on: hunt", "us", "tu.*sa.ron", ""},
+		{"t·a.r·on: hunt", "äp,ol,ei", "tä.po.*la.re.i.on", ""},
+		{"t·a.r·on: hunt", "eyk,äp", "tä.pey.*ka.ron", ""},
+		{"t·a.r·on: <am>: hunt", "iv", "ti.*va.ron", ""},
+		{"k··ä: go", "ìyev,äng", "kì.ye.vä.*ngä", ""},
+		{"t·a.r·on: hunt", "am,ol", "", `infix "ol": slot already taken by "am"`},
+		{"t·a.r·on: hunt", "ei,uy", "", `infix "uy": slot already taken by "ei"`},
+		{"t·a.r·on: hunt", "awn,ats", "", `infix "ats": can't be used with "awn"`},
+		{"t·a.r·on: hunt", "glurb", "", `infix "glurb": unknown affix`},
+		{"ta.ron: hunt", "us", "", "infixPos: missing"},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry+" "+row.infixes, func(t *testing.T) {
+			var infixes []string
+			if row.infixes != "" {
+				infixes = strings.Split(row.infixes, ",")
+			}
+
+			conjugation, err := Conjugate(*ParseEntry(row.entry), infixes)
+			if row.err != "" {
+				assert.EqualError(t, err, row.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, row.expected, DotSyllables(conjugation.Syllables, conjugation.Stress))
+			assert.Equal(t, strings.Join(conjugation.Syllables, ""), conjugation.Word)
+			assert.Equal(t, append([]string{}, infixes...), conjugation.Infixes)
+			assert.Equal(t, conjugation.Infixes, conjugation.Entry.Infixes)
+		})
+	}
+}
+
+func TestConjugations(t *testing.T) {
+	conjugations, err := Conjugations(*ParseEntry("t·a.r·on: hunt"))
+	assert.NoError(t, err)
+	// The participles us and awn can't take the four affect infixes, with or without one from slot 0.
+	assert.Len(t, conjugations, 4*25*5-4*2*4)
+
+	assert.Equal(t, []string{}, conjugations[0].Infixes)
+	assert.Equal(t, "taron", conjugations[0].Word)
+	assert.Equal(t, []string{"ei"}, conjugations[1].Infixes)
+	assert.Equal(t, []string{"äpeyk", "awn"}, conjugations[len(conjugations)-1].Infixes)
+
+	seen := make(map[string]bool, len(conjugations))
+	for _, conjugation := range conjugations {
+		key := strings.Join(conjugation.Infixes, ",")
+		assert.False(t, seen[key], key)
+		seen[key] = true
+
+		assert.NoError(t, litxaputil.ValidateInfixes(conjugation.Infixes))
+	}
+
+	_, err = Conjugations(*ParseEntry("ta.ron: hunt"))
+	assert.ErrorIs(t, err, litxaputil.ErrMissingField)
+}
@@ -2,6 +2,7 @@ package litxap
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 
 	"github.com/gissleh/litxap/litxaputil"
@@ -19,32 +20,158 @@ type Entry struct {
 	// InfixPos has a pair of positions, syllable, byte.
 	InfixPos *[2][2]int `json:"infixPos,omitempty"`
 
-	// Prefixes are an in-order list of prefixes
+	// Prefixes are an in-order list of prefixes. One written with litxaputil.StressMark, e.g. "*fì", takes the stress,
+	// and litxaputil.StressLater or StressEarlier after it, e.g. "fì>", moves the stress one syllable per mark.
 	Prefixes []string `json:"prefixes,omitempty"`
 	// Infixes are a list of infixes, order does not matter
 	Infixes []string `json:"infixes,omitempty"`
-	// Suffixes are an in-order list of suffixes
+	// Suffixes are an in-order list of suffixes, where the stress marks work as they do for Prefixes.
 	Suffixes []string `json:"suffixes,omitempty"`
+
+	// PartOfSpeech is optional, e.g. "n." or "adj.". It's needed to tell the attributive particle apart.
+	PartOfSpeech string `json:"partOfSpeech,omitempty"`
+}
+
+// IsAdjective is true if the part of speech is an adjective.
+func (entry *Entry) IsAdjective() bool {
+	return strings.HasPrefix(entry.PartOfSpeech, "adj")
 }
 
 func (entry *Entry) GenerateSyllables() ([]string, int, int) {
+	syllables, stress, offset, _ := entry.generateSyllables(nil, false)
+	return syllables, stress, offset
+}
+
+// GenerateSyllablesE is GenerateSyllables for entries that can't be trusted. Instead of guessing at unknown affixes
+// or panicking on bad positions, it returns a *litxaputil.AffixError naming the affix or field that is wrong.
+func (entry *Entry) GenerateSyllablesE() ([]string, int, int, error) {
+	return entry.generateSyllables(nil, true)
+}
+
+// GenerateStage is the syllables and stress after one of the steps in GenerateSyllables.
+type GenerateStage struct {
+	// Name is "root", "prefixes", "infixes" or "suffixes".
+	Name      string
+	Syllables []string
+	Stress    int
+	// Lenitions are the lenitions caused by the prefixes, e.g. "t→s". It's only set on the "prefixes" stage.
+	Lenitions []string
+}
+
+// GenerateStages does the same as GenerateSyllables, but also gives the syllables after each step. A step is left
+// out if the entry has none of its affixes. The root offset is returned along with the stages.
+func (entry *Entry) GenerateStages() ([]GenerateStage, int) {
+	stages, offset, _ := entry.generateStages(false)
+	return stages, offset
+}
+
+// GenerateStagesE is GenerateStages for entries that can't be trusted, see GenerateSyllablesE. On an error, the
+// stages are nil.
+func (entry *Entry) GenerateStagesE() ([]GenerateStage, int, error) {
+	return entry.generateStages(true)
+}
+
+func (entry *Entry) generateStages(strict bool) ([]GenerateStage, int, error) {
+	stages := make([]GenerateStage, 0, 4)
+	_, _, offset, err := entry.generateSyllables(func(name string, syllables []string, stress int, lenitions []string) {
+		stages = append(stages, GenerateStage{
+			Name:      name,
+			Syllables: append(syllables[:0:0], syllables...),
+			Stress:    stress,
+			Lenitions: lenitions,
+		})
+	}, strict)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return stages, offset, nil
+}
+
+func (entry *Entry) generateSyllables(stage func(name string, syllables []string, stress int, lenitions []string), strict bool) ([]string, int, int, error) {
+	if stage == nil {
+		stage = func(string, []string, int, []string) {}
+	}
+
+	if strict {
+		if err := entry.validate(); err != nil {
+			return nil, -1, 0, err
+		}
+	}
+
 	syllables := append(entry.Syllables[:0:0], entry.Syllables...)
 	stress := entry.Stress
-
-	syllables, offset := litxaputil.ApplyPrefixes(syllables, entry.Prefixes)
-	stress += offset
+	stage("root", syllables, stress, nil)
+
+	var offset int
+	var lenitions []string
+	var err error
+	if strict {
+		syllables, offset, stress, lenitions, err = litxaputil.InflectPrefixesE(syllables, entry.Prefixes, stress)
+		if err != nil {
+			return nil, -1, 0, err
+		}
+	} else {
+		syllables, offset, stress, lenitions = litxaputil.InflectPrefixes(syllables, entry.Prefixes, stress)
+	}
+	if len(entry.Prefixes) > 0 {
+		stage("prefixes", syllables, stress, lenitions)
+	}
 
 	if entry.InfixPos != nil && len(entry.Infixes) > 0 {
+		// Lenition and contractions can change the root's first syllable, e.g. ay- + tsa => sa, so the byte
+		// positions in it need to follow. A negative position is the lack of one, and stays that way.
 		positions := *entry.InfixPos
-		positions[0][0] += offset
-		positions[1][0] += offset
+		shift := len(syllables[offset]) - len(entry.Syllables[0])
+		for i := range positions {
+			if positions[i][0] < 0 {
+				continue
+			}
+			if positions[i][0] == 0 {
+				positions[i][1] = max(positions[i][1]+shift, 0)
+			}
+			positions[i][0] += offset
+		}
 
-		syllables, stress = litxaputil.ApplyInfixes(syllables, entry.Infixes, offset, stress, positions)
+		if strict {
+			syllables, stress, err = litxaputil.ApplyInfixesE(syllables, entry.Infixes, offset, stress, positions)
+			if err != nil {
+				return nil, -1, 0, err
+			}
+		} else {
+			syllables, stress = litxaputil.ApplyInfixes(syllables, entry.Infixes, offset, stress, positions)
+		}
+		stage("infixes", syllables, stress, nil)
 	}
 
-	syllables = litxaputil.ApplySuffixes(syllables, entry.Suffixes)
+	if strict {
+		syllables, stress, err = litxaputil.InflectSuffixesE(syllables, entry.Suffixes, stress)
+		if err != nil {
+			return nil, -1, 0, err
+		}
+	} else {
+		syllables, stress = litxaputil.InflectSuffixes(syllables, entry.Suffixes, stress)
+	}
+	if len(entry.Suffixes) > 0 {
+		stage("suffixes", syllables, stress, nil)
+	}
 
-	return syllables, stress, offset
+	return syllables, stress, offset, nil
+}
+
+// validate checks the fields that GenerateSyllables trusts to be right.
+func (entry *Entry) validate() error {
+	if len(entry.Syllables) == 0 {
+		return &litxaputil.AffixError{Kind: "syllables", Err: litxaputil.ErrEmptyWord}
+	}
+	if entry.Stress < 0 || entry.Stress >= len(entry.Syllables) {
+		return &litxaputil.AffixError{Kind: "stress", Name: strconv.Itoa(entry.Stress), Err: litxaputil.ErrInvalidPosition}
+	}
+	if len(entry.Infixes) > 0 && entry.InfixPos == nil {
+		return &litxaputil.AffixError{Kind: "infixPos", Err: litxaputil.ErrMissingField}
+	}
+
+	return nil
 }
 
 func (entry *Entry) String() string {
@@ -80,10 +207,15 @@ func (entry *Entry) String() string {
 	}
 
 	inflected := len(entry.Prefixes) > 0 || len(entry.Suffixes) > 0 || len(entry.Infixes) > 0
-	if inflected {
+	if inflected || entry.PartOfSpeech != "" {
 		sb.WriteByte(':')
 	}
 
+	if entry.PartOfSpeech != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(entry.PartOfSpeech)
+	}
+
 	if len(entry.Prefixes) > 0 {
 		sb.WriteByte(' ')
 		for _, prefix := range entry.Prefixes {
@@ -112,7 +244,7 @@ func (entry *Entry) String() string {
 	}
 
 	if len(entry.Translation) > 0 {
-		if !inflected {
+		if !inflected && entry.PartOfSpeech == "" {
 			sb.WriteString(": ")
 		}
 		sb.WriteString(": ")
@@ -123,7 +255,8 @@ func (entry *Entry) String() string {
 }
 
 // ParseEntry is admittedly a test-utility, but it's kept out here as Entry is a top-level object.
-// It parses the format that comes out Entry.String: e.g. "t·ì.*r·an: tì- <us> -ìri".
+// It parses the format that comes out Entry.String: e.g. "t·ì.*r·an: vin. tì- <us> -ìri", where the part of speech
+// is the one word in the middle that ends with a period.
 // In spite of the return type, it'll always give back an Entry.
 func ParseEntry(s string) *Entry {
 	split := strings.Split(s, ": ")
@@ -167,6 +300,9 @@ func ParseEntry(s string) *Entry {
 			if strings.HasPrefix(token, "<") && strings.HasSuffix(token, ">") {
 				entry.Infixes = strings.Split(token[len("<"):len(token)-len(">")], ",")
 			}
+			if strings.HasSuffix(token, ".") {
+				entry.PartOfSpeech = token
+			}
 		}
 	}
 
@@ -217,12 +353,18 @@ func (dm MultiDictionary) LookupMultis(word string) (LinePartMatch, error) {
 		return LinePartMatch{}, ErrEntryNotFound
 	}
 
-	entries, err := dm[0].LookupMultis(word)
-	if err != nil && !errors.Is(err, ErrEntryNotFound) {
-		return LinePartMatch{}, err
+	for _, dict := range dm {
+		match, err := dict.LookupMultis(word)
+		if err == nil {
+			return match, nil
+		}
+		if !errors.Is(err, ErrEntryNotFound) {
+			return LinePartMatch{}, err
+		}
 	}
 
-	return entries, nil
+	return LinePartMatch{}, ErrEntryNotFound
 }
 
 var ErrEntryNotFound = errors.New("entry not found")
+var ErrNoMatch = errors.New("does not match the entry")
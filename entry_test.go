@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gissleh/litxap/litxaputil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,6 +56,27 @@ func (b BrokenDictionary) LookupEntries(_ string) ([]Entry, error) {
 	return nil, errors.New("500 something something")
 }
 
+func TestMultiDictionary_LookupMultis(t *testing.T) {
+	md := MultiDictionary{MapDictionary{}, dummyDictionary}
+
+	res, err := md.LookupMultis("mìfa")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+	assert.Equal(t, LinePartMatch{}, res)
+
+	res, err = md.LookupMultis("fmetokyu")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fme", "tok", "yu"}, res.Syllables)
+
+	_, err = MultiDictionary{MapDictionary{}, BrokenDictionary{}}.LookupMultis("fmetokyu")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrEntryNotFound)
+
+	line, err := RunLine("Kaltxì, ma tsmukan", MultiDictionary{MapDictionary{"ma": {*ParseEntry("ma")}}, MapDictionary{}})
+	assert.NoError(t, err)
+	assert.Len(t, line[2].Matches, 1)
+	assert.Empty(t, line[4].Matches)
+}
+
 func TestParseEntry(t *testing.T) {
 	table := []string{
 		"tskxe",
@@ -65,6 +87,10 @@ func TestParseEntry(t *testing.T) {
 		"t·a.r·on: tì- <us> -ti: hunt",
 		"t·ì.*r·an: tì- <us> -ìri: walk",
 		"sä.*pxor: : explosion",
+		"kem: *fì-: this thing",
+		"fme.tok: -yu>: tester",
+		"sìl.tsan: adj.: good",
+		"t·a.r·on: vtr. tì- <us> -ti: hunt",
 	}
 
 	for _, row := range table {
@@ -75,6 +101,87 @@ func TestParseEntry(t *testing.T) {
 	}
 }
 
+func TestEntry_GenerateStages(t *testing.T) {
+	table := []struct {
+		entry  string
+		stages []string
+		offset int
+	}{
+		{"tskxe", []string{"root tskxe"}, 0},
+		{"u.*van: -ti", []string{"root u.*van", "suffixes u.*van.ti"}, 0},
+		{
+			"t·a.r·on: tì- <us> -ti: hunt",
+			[]string{"root ta.ron", "prefixes tì.*ta.ron", "infixes tì.tu.*sa.ron", "suffixes tì.tu.*sa.ron.ti"},
+			1,
+		},
+		{"tu.te: ay- -ta", []string{"root tu.te", "prefixes ay.*su.te t→s", "suffixes ay.*su.te.ta"}, 1},
+		{"tseng: *tsa- -*ri", []string{"root tseng", "prefixes tsa.tseng", "suffixes tsa.tseng.*ri"}, 1},
+		{"tseng: tsa- -ri", []string{"root tseng", "prefixes tsa.*tseng", "suffixes tsa.*tseng.ri"}, 1},
+		{"tseng: tsa<- -ri>", []string{"root tseng", "prefixes tsa.tseng", "suffixes tsa.*tseng.ri"}, 1},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry, func(t *testing.T) {
+			entry := ParseEntry(row.entry)
+			stages, offset := entry.GenerateStages()
+
+			res := make([]string, 0, len(stages))
+			for _, stage := range stages {
+				res = append(res, strings.Join(append([]string{stage.Name, DotSyllables(stage.Syllables, stage.Stress)}, stage.Lenitions...), " "))
+			}
+
+			assert.Equal(t, row.stages, res)
+			assert.Equal(t, row.offset, offset)
+
+			syllables, stress, _ := entry.GenerateSyllables()
+			assert.Equal(t, syllables, stages[len(stages)-1].Syllables)
+			assert.Equal(t, stress, stages[len(stages)-1].Stress)
+		})
+	}
+}
+
+func TestEntry_GenerateSyllablesE(t *testing.T) {
+	table := []struct {
+		entry Entry
+		err   string
+	}{
+		{*ParseEntry("t·a.r·on: tì- <us> -ti"), ""},
+		{*ParseEntry("i.*o.ang: tsa-fne- -hu"), ""},
+		{Entry{}, "syllables: no syllables to attach to"},
+		{Entry{Syllables: []string{"ta", "ron"}, Stress: 2}, `stress "2": position out of range`},
+		{Entry{Syllables: []string{"ta", "ron"}, Infixes: []string{"us"}}, "infixPos: missing"},
+		{*ParseEntry("ta.ron: fnetsa-"), `prefix "fnetsa": unknown affix`},
+		{*ParseEntry("t·a.r·on: <glurb>"), `infix "glurb": unknown affix`},
+		{*ParseEntry("ta.ron: -teriri"), `suffix "teriri": unknown affix`},
+		{Entry{Syllables: []string{"ta", "ron"}, InfixPos: &[2][2]int{{0, 1}, {-1, -1}}, Prefixes: []string{"tì"}, Infixes: []string{"us"}}, ""},
+		{
+			Entry{Syllables: []string{"ta", "ron"}, InfixPos: &[2][2]int{{0, 1}, {-1, -1}}, Prefixes: []string{"tì"}, Infixes: []string{"ei"}},
+			`infixPos "[-1 -1]": position out of range`,
+		},
+		{
+			Entry{Syllables: []string{"ron", "ì", "kx"}, InfixPos: &[2][2]int{{0, 0}, {-1, 2}}, Prefixes: []string{"tì"}, Infixes: []string{"ei", "ay"}},
+			`infixPos "[-1 2]": position out of range`,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry.String(), func(t *testing.T) {
+			syllables, stress, offset, err := row.entry.GenerateSyllablesE()
+			if row.err != "" {
+				assert.EqualError(t, err, row.err)
+				assert.Nil(t, syllables)
+				return
+			}
+
+			assert.NoError(t, err)
+			expectedSyllables, expectedStress, expectedOffset := row.entry.GenerateSyllables()
+			assert.Equal(t, expectedSyllables, syllables)
+			assert.Equal(t, expectedStress, stress)
+			assert.Equal(t, expectedOffset, offset)
+		})
+	}
+}
+
 func TestMultiDictionary_LookupEntries(t *testing.T) {
 	mdGood := MultiDictionary{
 		dummyDictionary,
@@ -115,3 +222,16 @@ func TestMultiDictionary_LookupEntries(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, res, []Entry{*ParseEntry("sa'.nok: -ur: nother")})
 }
+
+func TestEntry_GenerateStagesE(t *testing.T) {
+	entry := ParseEntry("t·a.r·on: tì- <us> -ti")
+	stages, offset, err := entry.GenerateStagesE()
+	assert.NoError(t, err)
+	expected, expectedOffset := entry.GenerateStages()
+	assert.Equal(t, expected, stages)
+	assert.Equal(t, expectedOffset, offset)
+
+	stages, _, err = ParseEntry("t·a.r·on: <glurb>").GenerateStagesE()
+	assert.Nil(t, stages)
+	assert.ErrorIs(t, err, litxaputil.ErrUnknownAffix)
+}
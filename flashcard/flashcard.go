@@ -0,0 +1,140 @@
+// Package flashcard collects the words of a text or dictionary into a deck of flashcards that can be imported into
+// Anki or any other program that reads CSV.
+package flashcard
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/litxaputil"
+)
+
+// Card is one word of the deck, with the fields written in the order of Columns.
+type Card struct {
+	// Surface is the word with the stressed syllable marked.
+	Surface string
+	// Syllables is the word with dots between the syllables, e.g. "kal.txì".
+	Syllables string
+	// IPA is the pronunciation, e.g. "kal.ˈt'ɪ".
+	IPA string
+	// Root is the Word of the entry.
+	Root string
+	// Affixes are written like in Entry.String, e.g. "tì- <us> -ti".
+	Affixes     string
+	Translation string
+	// Source is the line the word was first found in. It's empty for cards from a dictionary.
+	Source string
+}
+
+// Columns is the header row of the deck.
+var Columns = []string{"surface", "syllables", "ipa", "root", "affixes", "translation", "source"}
+
+func (card Card) record() []string {
+	return []string{card.Surface, card.Syllables, card.IPA, card.Root, card.Affixes, card.Translation, card.Source}
+}
+
+// Deck is a list of cards without duplicates. Two words are the same card if they have the same root and affixes,
+// and the first one added is the one that's kept.
+type Deck struct {
+	format litxap.WordFormatter
+	cards  []Card
+	seen   map[string]bool
+}
+
+// NewDeck creates an empty deck, which marks the stress on the cards with format.
+func NewDeck(format litxap.WordFormatter) *Deck {
+	return &Deck{format: format, seen: make(map[string]bool)}
+}
+
+// AddLine adds the matches of every word in the line, where text is the line it was run from. Words without matches
+// are left out.
+func (deck *Deck) AddLine(text string, line litxap.Line) {
+	for _, part := range line {
+		for _, match := range part.Matches {
+			deck.add(match.Entry, match.Syllables, match.Stress, text)
+		}
+	}
+}
+
+// AddEntries adds the entries with the syllables they generate. The entries that can't be generated are left out, and
+// their errors are returned together once the rest are added.
+func (deck *Deck) AddEntries(entries []litxap.Entry) error {
+	var errs []error
+	for _, entry := range entries {
+		syllables, stress, _, err := entry.GenerateSyllablesE()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.String(), err))
+			continue
+		}
+
+		deck.add(entry, syllables, stress, "")
+	}
+
+	return errors.Join(errs...)
+}
+
+// Cards lists the cards in the order they were added.
+func (deck *Deck) Cards() []Card {
+	return deck.cards
+}
+
+// Write writes the deck as CSV with a header row, using comma as the separator.
+func (deck *Deck) Write(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(Columns); err != nil {
+		return err
+	}
+	for _, card := range deck.cards {
+		if err := cw.Write(card.record()); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (deck *Deck) add(entry litxap.Entry, syllables []string, stress int, source string) {
+	if len(syllables) == 0 {
+		return
+	}
+
+	affixes := Affixes(entry)
+	key := strings.ToLower(entry.Word) + "|" + affixes
+	if deck.seen[key] {
+		return
+	}
+	deck.seen[key] = true
+
+	deck.cards = append(deck.cards, Card{
+		Surface:     deck.format(syllables, stress),
+		Syllables:   strings.Join(syllables, "."),
+		IPA:         litxaputil.SyllablesToIPA(syllables, stress),
+		Root:        entry.Word,
+		Affixes:     affixes,
+		Translation: entry.Translation,
+		Source:      source,
+	})
+}
+
+// Affixes lists the affixes of the entry like Entry.String does, e.g. "tì- <us> -ti", but without stress marks.
+func Affixes(entry litxap.Entry) string {
+	parts := make([]string, 0, 3)
+	if len(entry.Prefixes) > 0 {
+		parts = append(parts, strings.Join(litxaputil.AffixNames(entry.Prefixes), "-")+"-")
+	}
+	if len(entry.Infixes) > 0 {
+		parts = append(parts, "<"+strings.Join(entry.Infixes, ",")+">")
+	}
+	if len(entry.Suffixes) > 0 {
+		parts = append(parts, "-"+strings.Join(litxaputil.AffixNames(entry.Suffixes), "-"))
+	}
+
+	return strings.Join(parts, " ")
+}
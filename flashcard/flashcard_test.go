@@ -0,0 +1,96 @@
+package flashcard
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDictionary() litxap.MapDictionary {
+	return testdict.New(testdict.Basic, testdict.Kameie, []string{"t·a.r·on: tì- <us> -ti: hunting"})
+}
+
+func TestAffixes(t *testing.T) {
+	table := []struct {
+		notation string
+		expected string
+	}{
+		{"kal.*txì", ""},
+		{"t·a.r·on: tì- <us> -ti", "tì- <us> -ti"},
+		{"k··ä: <am,ei>", "<am,ei>"},
+		{"u.*van: fay-tsa- -ri-ri", "fay-tsa- -ri-ri"},
+		{"kem: *fì- -yu>-ti", "fì- -yu-ti"},
+	}
+
+	for _, row := range table {
+		t.Run(row.notation, func(t *testing.T) {
+			assert.Equal(t, row.expected, Affixes(*litxap.ParseEntry(row.notation)))
+		})
+	}
+}
+
+func TestDeck_AddLine(t *testing.T) {
+	deck := NewDeck(litxap.AccentStress)
+	for _, text := range []string{"Kaltxì, ma fmetokyu!", "Kameie, fmetokyu", "Tìtusaronti ma tsmukan"} {
+		line, err := litxap.RunLine(text, testDictionary())
+		assert.NoError(t, err)
+		deck.AddLine(text, line)
+	}
+
+	assert.Equal(t, []Card{
+		{Surface: "Kaltx\u00ec\u0301", Syllables: "Kal.txì", IPA: "kal.ˈt'ɪ", Root: "kaltxì", Translation: "hello", Source: "Kaltxì, ma fmetokyu!"},
+		{Surface: "má", Syllables: "ma", IPA: "ma", Root: "ma", Translation: "oh", Source: "Kaltxì, ma fmetokyu!"},
+		{Surface: "fmétokyu", Syllables: "fme.tok.yu", IPA: "ˈfmɛ.tok̚.ju", Root: "fmetok", Affixes: "-yu", Translation: "tester", Source: "Kaltxì, ma fmetokyu!"},
+		{Surface: "Kámeie", Syllables: "Ka.me.i.e", IPA: "ˈka.mɛ.i.ɛ", Root: "kame", Affixes: "<ei>", Translation: "see", Source: "Kameie, fmetokyu"},
+		{Surface: "Kameié", Syllables: "Ka.me.i.e", IPA: "ka.mɛ.i.ˈɛ", Root: "kä", Affixes: "<am,ei>", Translation: "go", Source: "Kameie, fmetokyu"},
+		{Surface: "Tìtusáronti", Syllables: "Tì.tu.sa.ron.ti", IPA: "tɪ.tu.ˈsa.ɾon.ti", Root: "taron", Affixes: "tì- <us> -ti", Translation: "hunting", Source: "Tìtusaronti ma tsmukan"},
+	}, deck.Cards())
+}
+
+func TestDeck_AddEntries(t *testing.T) {
+	entries, err := testDictionary().ListEntries()
+	assert.NoError(t, err)
+
+	deck := NewDeck(litxap.WrapStress("[", "]"))
+	assert.NoError(t, deck.AddEntries(entries))
+	assert.NoError(t, deck.AddEntries(entries))
+	assert.Len(t, deck.Cards(), len(entries))
+
+	for _, card := range deck.Cards() {
+		assert.Empty(t, card.Source)
+		assert.Contains(t, card.Surface, "[")
+	}
+}
+
+func TestDeck_AddEntries_Invalid(t *testing.T) {
+	deck := NewDeck(litxap.UpperStress)
+	err := deck.AddEntries([]litxap.Entry{
+		*litxap.ParseEntry("ta.ron: fnetsa-"),
+		*litxap.ParseEntry("fme.tok: -yu"),
+		{Word: "blarg"},
+	})
+
+	assert.ErrorIs(t, err, litxaputil.ErrUnknownAffix)
+	assert.ErrorIs(t, err, litxaputil.ErrEmptyWord)
+	assert.EqualError(t, err, "ta.ron: fnetsa-: prefix \"fnetsa\": unknown affix\n: syllables: no syllables to attach to")
+	assert.Len(t, deck.Cards(), 1)
+}
+
+func TestDeck_Write(t *testing.T) {
+	deck := NewDeck(litxap.AccentStress)
+	deck.AddEntries([]litxap.Entry{*litxap.ParseEntry("fme.tok: -yu: tester, one who tests")})
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, deck.Write(&buf, ','))
+	assert.Equal(t, "surface,syllables,ipa,root,affixes,translation,source\n"+
+		"fmétokyu,fme.tok.yu,ˈfmɛ.tok̚.ju,fmetok,-yu,\"tester, one who tests\",\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, deck.Write(&buf, '\t'))
+	assert.Equal(t, "surface\tsyllables\tipa\troot\taffixes\ttranslation\tsource\n"+
+		"fmétokyu\tfme.tok.yu\tˈfmɛ.tok̚.ju\tfmetok\t-yu\ttester, one who tests\t\n", buf.String())
+}
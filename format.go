@@ -0,0 +1,231 @@
+package litxap
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// A WordFormatter writes out a matched word from its syllables. The syllables are taken from the original text, so
+// joining them gives back the word as it was written. The stress is -1 if the matches could not agree on it.
+type WordFormatter func(syllables []string, stress int) string
+
+// WrapStress gives a WordFormatter that puts before and after around the stressed syllable, e.g. "<u>" and "</u>".
+func WrapStress(before, after string) WordFormatter {
+	return func(syllables []string, stress int) string {
+		sb := strings.Builder{}
+		for i, syllable := range syllables {
+			if i == stress {
+				sb.WriteString(before)
+				sb.WriteString(syllable)
+				sb.WriteString(after)
+			} else {
+				sb.WriteString(syllable)
+			}
+		}
+
+		return sb.String()
+	}
+}
+
+// UpperStress is a WordFormatter that writes the stressed syllable in uppercase, e.g. "kalTXÌ".
+func UpperStress(syllables []string, stress int) string {
+	sb := strings.Builder{}
+	for i, syllable := range syllables {
+		if i == stress {
+			sb.WriteString(strings.ToUpper(syllable))
+		} else {
+			sb.WriteString(syllable)
+		}
+	}
+
+	return sb.String()
+}
+
+// DotSyllables is a WordFormatter that writes the syllables in the notation of Entry.String: separated by dots,
+// with a * in front of the stressed syllable unless it's the first one.
+func DotSyllables(syllables []string, stress int) string {
+	sb := strings.Builder{}
+	for i, syllable := range syllables {
+		if i > 0 {
+			sb.WriteByte('.')
+			if i == stress {
+				sb.WriteByte('*')
+			}
+		}
+		sb.WriteString(syllable)
+	}
+
+	return sb.String()
+}
+
+// Syllables gives the syllables and stress that all the part's matches agree on. If they agree on the syllables, but
+// not on the stress, the stress is -1. If there are no matches, or they disagree on the syllables, it returns nil.
+func (part LinePart) Syllables() ([]string, int) {
+	if len(part.Matches) == 0 {
+		return nil, -1
+	}
+
+	first := part.Matches[0]
+	stress := first.Stress
+	for _, match := range part.Matches[1:] {
+		if len(match.Syllables) != len(first.Syllables) {
+			return nil, -1
+		}
+		for i := range match.Syllables {
+			if !strings.EqualFold(match.Syllables[i], first.Syllables[i]) {
+				return nil, -1
+			}
+		}
+
+		if match.Stress != stress {
+			stress = -1
+		}
+	}
+
+	return first.Syllables, stress
+}
+
+// Sources splits s, the text that the line was parsed from, into the text behind each part. ParseLine normalizes
+// apostrophes and leaves the "lookup|" out of Raw, so joining the Raw fields will not always give back s.
+func (line Line) Sources(s string) []string {
+	res := make([]string, 0, len(line))
+	pos := 0
+
+	for _, part := range line {
+		n := utf8.RuneCountInString(part.Raw)
+		if part.Lookup != "" {
+			n += utf8.RuneCountInString(part.Lookup) + 1
+		}
+
+		start := pos
+		pos = skipRunes(s, pos, n)
+		res = append(res, s[start:pos])
+	}
+
+	return res
+}
+
+// SourcePart is a part of a line as it was written in the text it was parsed from.
+type SourcePart struct {
+	// Text is the text behind the part, including the "lookup|" of a lookup override.
+	Text string
+	// Syllables is the raw word cut into the syllables that all matches agree on, or nil if they don't or it's not a
+	// word. Joined, they make up the end of Text.
+	Syllables []string
+	// Stress is the index of the stressed syllable, or -1 if it's not known.
+	Stress int
+}
+
+// SourceParts pairs up the parts of the line with the text in s, the text that the line was parsed from.
+func (line Line) SourceParts(s string) []SourcePart {
+	sources := line.Sources(s)
+	res := make([]SourcePart, 0, len(sources))
+
+	for i, source := range sources {
+		part := line[i]
+
+		syllables, stress := part.Syllables()
+		if !part.IsWord || syllables == nil {
+			res = append(res, SourcePart{Text: source, Stress: -1})
+			continue
+		}
+
+		rawStart := skipRunes(source, 0, utf8.RuneCountInString(source)-utf8.RuneCountInString(part.Raw))
+		sourceSyllables := splitSource(source[rawStart:], part.Raw, syllables)
+		if sourceSyllables == nil {
+			stress = -1
+		}
+
+		res = append(res, SourcePart{Text: source, Syllables: sourceSyllables, Stress: stress})
+	}
+
+	return res
+}
+
+// Format writes the line back out as s, the text it was parsed from, with format applied to the words where all
+// matches agree on the syllables. Everything else is copied from s as-is, so formatting every word as
+// strings.Join(syllables, "") reproduces s exactly.
+func (line Line) Format(s string, format WordFormatter) string {
+	sb := strings.Builder{}
+	sb.Grow(len(s) + len(s)/2)
+
+	for _, part := range line.SourceParts(s) {
+		if part.Syllables == nil {
+			sb.WriteString(part.Text)
+			continue
+		}
+
+		// Keep the "lookup|" as it was written, only the raw part is formatted.
+		sb.WriteString(part.Prefix())
+		sb.WriteString(format(part.Syllables, part.Stress))
+	}
+
+	return sb.String()
+}
+
+// Prefix is the part of the text that comes before the syllables, which is the "lookup|" of a lookup override.
+func (part SourcePart) Prefix() string {
+	n := 0
+	for _, syllable := range part.Syllables {
+		n += len(syllable)
+	}
+
+	return part.Text[:len(part.Text)-n]
+}
+
+// FormatLine parses and runs s, and then formats it with Line.Format.
+func FormatLine(s string, dictionary Dictionary, format WordFormatter) (string, Line, error) {
+	line, err := RunLine(s, dictionary)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return line.Format(s, format), line, nil
+}
+
+// Unknown lists the words in the line without any matches.
+func (line Line) Unknown() []string {
+	var res []string
+	for _, part := range line {
+		if part.IsWord && len(part.Matches) == 0 {
+			res = append(res, part.Raw)
+		}
+	}
+
+	return res
+}
+
+// splitSource cuts source into the same syllables that the matcher cut raw into. The syllables may not add up to the
+// raw word when they came from a lookup override, and nil is returned if that is the case.
+func splitSource(source, raw string, syllables []string) []string {
+	total := 0
+	for _, syllable := range syllables {
+		total += len(syllable)
+	}
+	if total != len(raw) {
+		return nil
+	}
+
+	res := make([]string, 0, len(syllables))
+	rawPos := 0
+	sourcePos := 0
+	for _, syllable := range syllables {
+		n := utf8.RuneCountInString(raw[rawPos : rawPos+len(syllable)])
+		start := sourcePos
+		sourcePos = skipRunes(source, sourcePos, n)
+		rawPos += len(syllable)
+
+		res = append(res, source[start:sourcePos])
+	}
+
+	return res
+}
+
+func skipRunes(s string, pos, n int) int {
+	for i := 0; i < n && pos < len(s); i++ {
+		_, size := utf8.DecodeRuneInString(s[pos:])
+		pos += size
+	}
+
+	return pos
+}
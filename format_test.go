@@ -0,0 +1,126 @@
+package litxap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine_Format(t *testing.T) {
+	table := []struct {
+		input    string
+		expected string
+	}{
+		{"Kaltxì, ma fmetokyu!", "Kal<u>txì</u>, <u>ma</u> <u>fme</u>tokyu!"},
+		{"Oel ngati kameie.", "<u>O</u>el <u>nga</u>ti <u>ka</u>meie."},
+		{"Vola skeynven.", "<u>Vo</u>la skeynven."},
+		{"Vola säkeynven|skeynven.", "<u>Vo</u>la säkeynven|skeynven."},
+		{"Ma ’eylan, kaltxì", "<u>Ma</u> <u>’ey</u>lan, kal<u>txì</u>"},
+		{"", ""},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			res, _, err := FormatLine(row.input, dummyDictionary, WrapStress("<u>", "</u>"))
+			assert.NoError(t, err)
+			assert.Equal(t, row.expected, res)
+
+			line, err := RunLine(row.input, dummyDictionary)
+			assert.NoError(t, err)
+			assert.Equal(t, row.input, line.Format(row.input, func(syllables []string, _ int) string {
+				return strings.Join(syllables, "")
+			}))
+		})
+	}
+}
+
+func TestFormatLine_Fail(t *testing.T) {
+	res, line, err := FormatLine("Kaltxì", BrokenDictionary{}, WrapStress("[", "]"))
+	assert.Error(t, err)
+	assert.Empty(t, res)
+	assert.Nil(t, line)
+}
+
+func TestLinePart_Syllables(t *testing.T) {
+	table := []struct {
+		part      LinePart
+		syllables []string
+		stress    int
+	}{
+		{LinePart{Raw: "Kaltxì", IsWord: true}, nil, -1},
+		{
+			LinePart{Raw: "Kaltxì", IsWord: true, Matches: []LinePartMatch{
+				{[]string{"Kal", "txì"}, 1, dummyDictionary["kaltxì"]},
+			}},
+			[]string{"Kal", "txì"}, 1,
+		},
+		{
+			LinePart{Raw: "kameie", IsWord: true, Matches: []LinePartMatch{
+				{[]string{"ka", "me", "i", "e"}, 0, dummyDictionary["kameie"]},
+				{[]string{"ka", "me", "i", "e"}, 3, dummyDictionary["kameie:0"]},
+			}},
+			[]string{"ka", "me", "i", "e"}, -1,
+		},
+		{
+			LinePart{Raw: "tsukkan", IsWord: true, Matches: []LinePartMatch{
+				{[]string{"tsuk", "kan"}, 1, Entry{}},
+				{[]string{"tsu", "kkan"}, 1, Entry{}},
+			}},
+			nil, -1,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.part.Raw, func(t *testing.T) {
+			syllables, stress := row.part.Syllables()
+			assert.Equal(t, row.syllables, syllables)
+			assert.Equal(t, row.stress, stress)
+		})
+	}
+}
+
+func TestLine_Sources(t *testing.T) {
+	input := "’Awa säkeynven|skeynven aean-na-pay"
+	line := ParseLine(input)
+
+	assert.Equal(t, []string{"’Awa", " ", "säkeynven|skeynven", " ", "aean", "-", "na", "-", "pay"}, line.Sources(input))
+}
+
+func TestLine_Unknown(t *testing.T) {
+	line, err := RunLine("Vola skeynven, ma kifkey.", dummyDictionary)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"skeynven", "kifkey"}, line.Unknown())
+}
+
+func TestLine_SourceParts(t *testing.T) {
+	input := "Ma ’eylan, säkeynven|skeynven kameie"
+	line, err := RunLine(input, dummyDictionary)
+	assert.NoError(t, err)
+
+	parts := line.SourceParts(input)
+	assert.Equal(t, []SourcePart{
+		{Text: "Ma", Syllables: []string{"Ma"}, Stress: 0},
+		{Text: " ", Stress: -1},
+		{Text: "’eylan", Syllables: []string{"’ey", "lan"}, Stress: 0},
+		{Text: ", ", Stress: -1},
+		{Text: "säkeynven|skeynven", Stress: -1},
+		{Text: " ", Stress: -1},
+		{Text: "kameie", Syllables: []string{"ka", "me", "i", "e"}, Stress: 0},
+	}, parts)
+	assert.Equal(t, "", parts[0].Prefix())
+	assert.Equal(t, " ", parts[1].Prefix())
+}
+
+func TestUpperStress(t *testing.T) {
+	assert.Equal(t, "KalTXÌ", UpperStress([]string{"Kal", "txì"}, 1))
+	assert.Equal(t, "FMEtokyu", UpperStress([]string{"fme", "tok", "yu"}, 0))
+	assert.Equal(t, "kameie", UpperStress([]string{"ka", "me", "i", "e"}, -1))
+}
+
+func TestDotSyllables(t *testing.T) {
+	assert.Equal(t, "Kal.*txì", DotSyllables([]string{"Kal", "txì"}, 1))
+	assert.Equal(t, "fme.tok.yu", DotSyllables([]string{"fme", "tok", "yu"}, 0))
+	assert.Equal(t, "ka.me.i.e", DotSyllables([]string{"ka", "me", "i", "e"}, -1))
+	assert.Equal(t, "ma", DotSyllables([]string{"ma"}, 0))
+}
@@ -0,0 +1,192 @@
+// Package golden checks stress results against a golden file, so that changes to a dictionary or to litxap that
+// change the results are noticed.
+//
+// A golden file has one case per line: the text, a tab, and the expected result. The result lists the words of the
+// line in the notation of litxap.DotSyllables, e.g. "Kal.*txì ma fme.tok.yu". An unknown word is written as "?",
+// and the readings of a word are separated by "|" when the matches disagree. Blank lines and lines starting with #
+// are kept as they are.
+package golden
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// File is a golden file.
+type File struct {
+	Lines []Line
+}
+
+// Line is a line of a golden file. Only lines with IsCase set are checked, the rest are kept as Text.
+type Line struct {
+	IsCase   bool
+	Text     string
+	Expected string
+}
+
+// Result is the outcome of checking one case.
+type Result struct {
+	// LineNo is the one-based line number of the case in the golden file.
+	LineNo   int
+	Text     string
+	Expected string
+	Actual   string
+}
+
+// Failed is true if the result is not what was expected.
+func (res Result) Failed() bool {
+	return res.Actual != res.Expected
+}
+
+// Read reads a golden file. A case without a tab is read with an empty expectation, so it will fail until the file
+// is updated.
+func Read(r io.Reader) (*File, error) {
+	file := &File{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		s := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(s) == "" || strings.HasPrefix(s, "#") {
+			file.Lines = append(file.Lines, Line{Text: s})
+			continue
+		}
+
+		text, expected, _ := strings.Cut(s, "\t")
+		file.Lines = append(file.Lines, Line{IsCase: true, Text: text, Expected: expected})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Check runs every case against the dictionary.
+func (file *File) Check(dict litxap.Dictionary) ([]Result, error) {
+	results := make([]Result, 0, len(file.Lines))
+	for i, line := range file.Lines {
+		if !line.IsCase {
+			continue
+		}
+
+		result, err := litxap.RunLine(line.Text, dict)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		results = append(results, Result{
+			LineNo:   i + 1,
+			Text:     line.Text,
+			Expected: line.Expected,
+			Actual:   Notation(result),
+		})
+	}
+
+	return results, nil
+}
+
+// Update sets the expectations to the actual results.
+func (file *File) Update(results []Result) {
+	for _, result := range results {
+		file.Lines[result.LineNo-1].Expected = result.Actual
+	}
+}
+
+// WriteTo writes the golden file.
+func (file *File) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	written := 0
+
+	for _, line := range file.Lines {
+		n := 0
+		if line.IsCase {
+			n, _ = fmt.Fprintf(bw, "%s\t%s\n", line.Text, line.Expected)
+		} else {
+			n, _ = fmt.Fprintf(bw, "%s\n", line.Text)
+		}
+
+		written += n
+	}
+
+	return int64(written), bw.Flush()
+}
+
+// Notation writes the words of the line in the notation of the golden file.
+func Notation(line litxap.Line) string {
+	words := make([]string, 0, len(line))
+	for _, part := range line {
+		if !part.IsWord {
+			continue
+		}
+
+		if len(part.Matches) == 0 {
+			words = append(words, "?")
+			continue
+		}
+
+		if syllables, stress := part.Syllables(); syllables != nil && stress >= 0 {
+			words = append(words, litxap.DotSyllables(syllables, stress))
+			continue
+		}
+
+		readings := make([]string, 0, len(part.Matches))
+		for _, match := range part.Matches {
+			reading := litxap.DotSyllables(match.Syllables, match.Stress)
+			if !containsFold(readings, reading) {
+				readings = append(readings, reading)
+			}
+		}
+
+		words = append(words, strings.Join(readings, "|"))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// WriteDiff writes the failed results as a diff, with the words that changed listed under each line. It returns the
+// number of failed results.
+func WriteDiff(w io.Writer, name string, results []Result) (int, error) {
+	bw := bufio.NewWriter(w)
+	failed := 0
+
+	for _, result := range results {
+		if !result.Failed() {
+			continue
+		}
+
+		failed++
+		fmt.Fprintf(bw, "%s:%d: %s\n", name, result.LineNo, result.Text)
+		fmt.Fprintf(bw, "-\t%s\n", result.Expected)
+		fmt.Fprintf(bw, "+\t%s\n", result.Actual)
+
+		expected := strings.Fields(result.Expected)
+		actual := strings.Fields(result.Actual)
+		if len(expected) == len(actual) {
+			for i := range expected {
+				if expected[i] != actual[i] {
+					fmt.Fprintf(bw, "\tword %d: %s -> %s\n", i+1, expected[i], actual[i])
+				}
+			}
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(bw, "%d of %d lines changed\n", failed, len(results))
+	}
+
+	return failed, bw.Flush()
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
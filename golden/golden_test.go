@@ -0,0 +1,90 @@
+package golden
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDictionary() litxap.MapDictionary {
+	return testdict.New(testdict.Basic, testdict.Kameie)
+}
+
+const testFile = "# greetings\n" +
+	"Kaltxì, ma fmetokyu!\tKal.*txì ma fme.tok.yu\n" +
+	"\n" +
+	"ma tsmukan\t? ?\n" +
+	"kameie\tka.me.i.e|ka.me.i.*e\n" +
+	"ma kaltxì\n"
+
+func TestNotation(t *testing.T) {
+	table := []struct {
+		line     string
+		notation string
+	}{
+		{"Kaltxì, ma fmetokyu!", "Kal.*txì ma fme.tok.yu"},
+		{"ma tsmukan", "ma ?"},
+		{"Kameie", "Ka.me.i.e|Ka.me.i.*e"},
+		{"...", ""},
+	}
+
+	for _, row := range table {
+		t.Run(row.line, func(t *testing.T) {
+			line, err := litxap.RunLine(row.line, testDictionary())
+			assert.NoError(t, err)
+			assert.Equal(t, row.notation, Notation(line))
+		})
+	}
+}
+
+func TestFile_Check(t *testing.T) {
+	file, err := Read(strings.NewReader(testFile))
+	assert.NoError(t, err)
+	assert.Len(t, file.Lines, 6)
+
+	results, err := file.Check(testDictionary())
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{
+		{LineNo: 2, Text: "Kaltxì, ma fmetokyu!", Expected: "Kal.*txì ma fme.tok.yu", Actual: "Kal.*txì ma fme.tok.yu"},
+		{LineNo: 4, Text: "ma tsmukan", Expected: "? ?", Actual: "ma ?"},
+		{LineNo: 5, Text: "kameie", Expected: "ka.me.i.e|ka.me.i.*e", Actual: "ka.me.i.e|ka.me.i.*e"},
+		{LineNo: 6, Text: "ma kaltxì", Expected: "", Actual: "ma kal.*txì"},
+	}, results)
+
+	diff := bytes.Buffer{}
+	failed, err := WriteDiff(&diff, "test.txt", results)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, failed)
+	assert.Equal(t, "test.txt:4: ma tsmukan\n"+
+		"-\t? ?\n"+
+		"+\tma ?\n"+
+		"\tword 1: ? -> ma\n"+
+		"test.txt:6: ma kaltxì\n"+
+		"-\t\n"+
+		"+\tma kal.*txì\n"+
+		"2 of 4 lines changed\n", diff.String())
+
+	file.Update(results)
+	out := bytes.Buffer{}
+	_, err = file.WriteTo(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, "# greetings\n"+
+		"Kaltxì, ma fmetokyu!\tKal.*txì ma fme.tok.yu\n"+
+		"\n"+
+		"ma tsmukan\tma ?\n"+
+		"kameie\tka.me.i.e|ka.me.i.*e\n"+
+		"ma kaltxì\tma kal.*txì\n", out.String())
+
+	results, err = file.Check(testDictionary())
+	assert.NoError(t, err)
+	failed, err = WriteDiff(&diff, "test.txt", results)
+	assert.NoError(t, err)
+	assert.Zero(t, failed)
+
+	_, err = file.Check(testdict.Broken{})
+	assert.ErrorContains(t, err, "line 2: ")
+}
@@ -0,0 +1,75 @@
+package litxap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SoftHyphen is the invisible break point that word processors and browsers hyphenate at.
+const SoftHyphen = "\u00ad"
+
+// BreakSyllables gives a WordFormatter that puts marker between the syllables of the word. Where there is a hyphen
+// already, like in "fnemo-o", no marker is added next to it.
+func BreakSyllables(marker string) WordFormatter {
+	return func(syllables []string, _ int) string {
+		sb := strings.Builder{}
+		for i, syllable := range syllables {
+			if i > 0 && !strings.HasSuffix(syllables[i-1], "-") && !strings.HasPrefix(syllable, "-") {
+				sb.WriteString(marker)
+			}
+			sb.WriteString(syllable)
+		}
+
+		return sb.String()
+	}
+}
+
+// An EntryLister is a Dictionary that can list every entry in it.
+type EntryLister interface {
+	ListEntries() ([]Entry, error)
+}
+
+// WriteTeXHyphenation writes a \hyphenation{} exception list with the syllable breaks of the dictionary's entries,
+// including their affixes. TeX only accepts letters in the list, so words with apostrophes or spaces are left out,
+// and so are words of one syllable.
+func WriteTeXHyphenation(w io.Writer, dict EntryLister) error {
+	entries, err := dict.ListEntries()
+	if err != nil {
+		return err
+	}
+
+	words := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		syllables, _, _, err := entry.GenerateSyllablesE()
+		if err != nil {
+			return fmt.Errorf("%s: %w", entry.String(), err)
+		}
+		if len(syllables) < 2 {
+			continue
+		}
+
+		word := strings.ToLower(strings.Join(syllables, "-"))
+		if strings.ContainsFunc(word, func(r rune) bool { return r != '-' && !unicode.IsLetter(r) }) {
+			continue
+		}
+
+		words = append(words, word)
+	}
+
+	slices.Sort(words)
+	words = slices.Compact(words)
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("\\hyphenation{\n")
+	for _, word := range words {
+		bw.WriteString(word)
+		bw.WriteByte('\n')
+	}
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
@@ -0,0 +1,64 @@
+package litxap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakSyllables(t *testing.T) {
+	table := []struct {
+		input    string
+		expected string
+	}{
+		{"Kaltxì, ma fmetokyu!", "Kal\u00adtxì, ma fme\u00adtok\u00adyu!"},
+		{"Oel ngati kameie.", "O\u00adel nga\u00adti ka\u00adme\u00adi\u00ade."},
+		{"Vola skeynven.", "Vo\u00adla skeynven."},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			res, _, err := FormatLine(row.input, dummyDictionary, BreakSyllables(SoftHyphen))
+			assert.NoError(t, err)
+			assert.Equal(t, row.expected, res)
+		})
+	}
+}
+
+func TestBreakSyllables_Hyphens(t *testing.T) {
+	format := BreakSyllables("|")
+
+	assert.Equal(t, "fne|mo-o", format([]string{"fne", "mo", "-o"}, 1))
+	assert.Equal(t, "zek|wä-ä|o", format([]string{"zek", "wä-", "ä", "o"}, 0))
+	assert.Equal(t, "ma", format([]string{"ma"}, 0))
+}
+
+func TestWriteTeXHyphenation(t *testing.T) {
+	dict := MapDictionary{"": {
+		*ParseEntry("kal.*txì"),
+		*ParseEntry("ma"),
+		*ParseEntry("fme.tok: -yu"),
+		*ParseEntry("t·ì.*r·an: tì- <us> -ìri"),
+		*ParseEntry("let.*'ey.lan"),
+		*ParseEntry("Kal.*txì"),
+		*ParseEntry("tskxe.keng. s··i"),
+	}}
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, WriteTeXHyphenation(&buf, dict))
+	assert.Equal(t, "\\hyphenation{\nfme-tok-yu\nkal-txì\ntì-tu-sì-ra-nì-ri\n}\n", buf.String())
+
+	err := WriteTeXHyphenation(&buf, MapDictionary{"taronteriri": {*ParseEntry("ta.ron: -teriri")}})
+	assert.EqualError(t, err, `ta.ron: -teriri: suffix "teriri": unknown affix`)
+
+	err = WriteTeXHyphenation(&buf, brokenLister{})
+	assert.EqualError(t, err, "500 something something")
+}
+
+type brokenLister struct{}
+
+func (brokenLister) ListEntries() ([]Entry, error) {
+	return nil, errors.New("500 something something")
+}
@@ -0,0 +1,44 @@
+// Package testdict has the small dictionaries that the tests of the other packages run their text through.
+package testdict
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// Basic has the words of "Kaltxì, ma fmetokyu!".
+var Basic = []string{"kal.*txì: : hello", "ma: : oh", "fme.tok: -yu: tester"}
+
+// Kameie has the two readings of "kameie", which differ in stress.
+var Kameie = []string{"kameie\tk·a.m·e: <ei>: see", "kameie\tk··ä: <am,ei>: go"}
+
+// New makes a dictionary of the sets of lines, which are in the text format of litxap.ReadDictionary. It panics if
+// one of them can't be read, as the lines are part of the test.
+func New(sets ...[]string) litxap.MapDictionary {
+	var lines []string
+	for _, set := range sets {
+		lines = append(lines, set...)
+	}
+
+	dict, err := litxap.ReadDictionary(strings.NewReader(strings.Join(lines, "\n")), litxap.DictionaryText)
+	if err != nil {
+		panic(err)
+	}
+
+	return dict
+}
+
+// Broken is a dictionary where every lookup fails, like a dictionary service that's down.
+type Broken struct{}
+
+func (Broken) LookupEntries(string) ([]litxap.Entry, error) {
+	return nil, ErrBroken
+}
+
+func (Broken) LookupMultis(string) (litxap.LinePartMatch, error) {
+	return litxap.LinePartMatch{}, ErrBroken
+}
+
+var ErrBroken = errors.New("500 something something")
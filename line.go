@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
-	"unicode/utf8"
+
+	"github.com/gissleh/litxap/litxaputil"
 )
 
 func RunLine(line string, dictionary Dictionary) (Line, error) {
@@ -15,51 +16,136 @@ func RunLine(line string, dictionary Dictionary) (Line, error) {
 type Line []LinePart
 
 func (line Line) Run(dict Dictionary) (Line, error) {
+	return line.RunIn(dict, litxaputil.DialectAny)
+}
+
+// RunIn is Run for a line in the dialect, see RunWordIn.
+func (line Line) RunIn(dict Dictionary, dialect litxaputil.Dialect) (Line, error) {
 	newLine := append(line[:0:0], line...)
 
+	particles := make([]int, 0, 2)
 	for i, part := range newLine {
 		if !part.IsWord {
 			continue
 		}
 
-		lookup1 := part.Raw
-		if part.Lookup != "" {
-			lookup1 = part.Lookup
+		// A bare "a" may be the attributive particle, which depends on the words around it.
+		if isParticleA(part) {
+			particles = append(particles, i)
+			continue
+		}
+
+		if err := newLine.lookup(i, dict, dialect); err != nil {
+			return nil, err
 		}
+	}
 
-		results0, err0 := dict.LookupMultis(lookup1)
+	for _, i := range particles {
+		if newLine.nextToAdjective(i) {
+			newLine[i].Matches = []LinePartMatch{{
+				Syllables: []string{newLine[i].Raw},
+				Stress:    0,
+				Entry:     attributiveParticle,
+			}}
 
-		// If it's in either place, see the Romanization
-		if err0 == nil {
-			newLine[i].Matches = append(newLine[i].Matches, results0)
 			continue
 		}
 
-		// Look it up in the normal dictionary now
-		results, err := dict.LookupEntries(lookup1)
+		if err := newLine.lookup(i, dict, dialect); err != nil {
+			return nil, err
+		}
+	}
+
+	return newLine, nil
+}
+
+// lookup looks up the word at i and adds its matches.
+func (line Line) lookup(i int, dict Dictionary, dialect litxaputil.Dialect) error {
+	part := line[i]
+
+	lookup1 := part.Raw
+	if part.Lookup != "" {
+		lookup1 = part.Lookup
+	}
+
+	results0, err0 := dict.LookupMultis(lookup1)
+
+	// If it's in either place, see the Romanization
+	if err0 == nil {
+		line[i].Matches = append(line[i].Matches, results0)
+		return nil
+	}
+
+	// Look it up in the normal dictionary now
+	results, err := dict.LookupEntries(lookup1)
+
+	if err != nil {
+
+		if errors.Is(err, ErrEntryNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to lookup \"%s\": %w", lookup1, err)
+	}
 
+	for _, result := range results {
+		syllables, stress, err := runWordE(part.Raw, result, dialect)
 		if err != nil {
+			line[i].Errors = append(line[i].Errors, MatchError{Entry: result, Message: err.Error()})
+			continue
+		}
+
+		if syllables == nil || stress < 0 {
+			err := fmt.Errorf("word %q: %w", part.Raw, ErrNoMatch)
+			line[i].Errors = append(line[i].Errors, MatchError{Entry: result, Message: err.Error()})
+			continue
+		}
+
+		line[i].Matches = append(line[i].Matches, LinePartMatch{
+			Syllables: syllables,
+			Stress:    stress,
+			Entry:     result,
+		})
+	}
+
+	return nil
+}
+
+// attributiveParticle is the entry given to a bare "a" next to an adjective, as in "sìltsan a tìkan".
+var attributiveParticle = Entry{
+	Word:         "a",
+	Translation:  "attributive particle",
+	Syllables:    []string{"a"},
+	PartOfSpeech: "part.",
+}
+
+func isParticleA(part LinePart) bool {
+	return part.IsWord && part.Lookup == "" && strings.EqualFold(part.Raw, "a")
+}
+
+// nextToAdjective checks if the word at i is next to a word that matched an adjective, with only spaces between them.
+func (line Line) nextToAdjective(i int) bool {
+	for _, step := range []int{-1, 1} {
+		for j := i + step; j >= 0 && j < len(line); j += step {
+			if !line[j].IsWord {
+				if strings.TrimSpace(line[j].Raw) != "" {
+					break
+				}
 
-			if errors.Is(err, ErrEntryNotFound) {
 				continue
 			}
 
-			return nil, fmt.Errorf("failed to lookup \"%s\": %w", lookup1, err)
-		}
-
-		for _, result := range results {
-			syllables, stress := RunWord(part.Raw, result)
-			if syllables != nil && stress >= 0 {
-				newLine[i].Matches = append(newLine[i].Matches, LinePartMatch{
-					Syllables: syllables,
-					Stress:    stress,
-					Entry:     result,
-				})
+			for _, match := range line[j].Matches {
+				if match.Entry.IsAdjective() {
+					return true
+				}
 			}
+
+			break
 		}
 	}
 
-	return newLine, nil
+	return false
 }
 
 // ParseLine splits out the words from a line of text.
@@ -67,12 +153,13 @@ func ParseLine(s string) Line {
 	wordMode := false
 	lastPos := 0
 	lastPipe := 0
-	currentPos := 0
 	res := make(Line, 0, (len(s)/5)+1)
 
 	s = strings.NewReplacer("’", "'", "‘", "'").Replace(s) + "\n"
 
-	for _, ch := range s {
+	// The position comes from range rather than adding up the rune lengths, since an invalid byte is read as a
+	// three-byte U+FFFD.
+	for currentPos, ch := range s {
 		if ch == '|' {
 			lastPipe = currentPos
 		} else if ch == '\n' || wordMode != (unicode.IsLetter(ch) || ch == '\'' || ch == '-') {
@@ -112,8 +199,6 @@ func ParseLine(s string) Line {
 
 			wordMode = !wordMode
 		}
-
-		currentPos += utf8.RuneLen(ch)
 	}
 
 	return res
@@ -124,6 +209,14 @@ type LinePart struct {
 	Lookup  string          `json:"lookup,omitempty"`
 	IsWord  bool            `json:"isWord,omitempty"`
 	Matches []LinePartMatch `json:"matches,omitempty"`
+	// Errors are the entries that were found for the word, but are broken or don't match it, and why.
+	Errors []MatchError `json:"errors,omitempty"`
+}
+
+// MatchError is an entry that could not be matched, and why.
+type MatchError struct {
+	Entry   Entry  `json:"entry"`
+	Message string `json:"message"`
 }
 
 type LinePartMatch struct {
@@ -1,8 +1,10 @@
 package litxap
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/gissleh/litxap/litxaputil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -193,16 +195,84 @@ func TestRunLine(t *testing.T) {
 
 	for _, row := range table {
 		t.Run(row.input, func(t *testing.T) {
-			res, err := RunLine(row.input, dummyDictionary, mustDouble)
+			res, err := RunLine(row.input, dummyDictionary)
 			assert.NoError(t, err)
 			assert.Equal(t, row.expected, res)
 		})
 	}
 }
 
+func TestRunLine_AttributiveParticle(t *testing.T) {
+	dict := DummyDictionary{
+		"sìltsan": *ParseEntry("sìl.tsan: adj.: good"),
+		"tìkan":   *ParseEntry("tì.*kan: n.: aim"),
+		"a":       *ParseEntry("a: part.: which, that"),
+	}
+
+	table := []struct {
+		input   string
+		entries []string
+	}{
+		{"sìltsan a tìkan", []string{"good", "attributive particle", "aim"}},
+		{"tìkan A sìltsan", []string{"aim", "attributive particle", "good"}},
+		{"tìkan a tìkan", []string{"aim", "which, that", "aim"}},
+		{"sìltsan, a tìkan", []string{"good", "which, that", "aim"}},
+		{"sìltsan a|a tìkan", []string{"good", "which, that", "aim"}},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			line, err := RunLine(row.input, dict)
+			assert.NoError(t, err)
+
+			entries := make([]string, 0, len(row.entries))
+			for _, part := range line {
+				if part.IsWord {
+					assert.Len(t, part.Matches, 1)
+					entries = append(entries, part.Matches[0].Entry.Translation)
+				}
+			}
+			assert.Equal(t, row.entries, entries)
+		})
+	}
+
+	line, err := RunLine("tìkan A sìltsan", dict)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A"}, line[2].Matches[0].Syllables)
+	assert.Equal(t, 0, line[2].Matches[0].Stress)
+}
+
+func TestRunLine_AttributiveParticle_TextDictionary(t *testing.T) {
+	dict, err := ReadDictionary(strings.NewReader("sìl.tsan: adj.: good\ntì.*kan: n.: aim\n"), DictionaryText)
+	assert.NoError(t, err)
+
+	line, err := RunLine("sìltsan a tìkan", dict)
+	assert.NoError(t, err)
+	assert.Equal(t, "attributive particle", line[2].Matches[0].Entry.Translation)
+}
+
+func TestRunLine_BrokenEntry(t *testing.T) {
+	broken := Entry{Word: "tsmukan", Syllables: []string{"tsmu", "kan"}, Suffixes: []string{"teriri"}}
+	dict := MapDictionary{"ma": {*ParseEntry("ma"), broken}}
+
+	line, err := RunLine("Ma tsmukan", dict)
+	assert.NoError(t, err)
+	assert.Len(t, line[0].Matches, 1)
+	assert.Equal(t, []MatchError{{Entry: broken, Message: `suffix "teriri": unknown affix`}}, line[0].Errors)
+}
+
+func TestRunLine_NoMatch(t *testing.T) {
+	entry := *ParseEntry("fme.tok: -yu")
+	dict := MapDictionary{"fmetokyu": {entry}}
+
+	line, err := RunLine("fmetokyu|fmetokti", dict)
+	assert.NoError(t, err)
+	assert.Empty(t, line[0].Matches)
+	assert.Equal(t, []MatchError{{Entry: entry, Message: `word "fmetokti": does not match the entry`}}, line[0].Errors)
+}
+
 func TestRunLine_Fail(t *testing.T) {
-	doubles := map[string]string{"tsaheyl": "si"}
-	line, err := RunLine("Kaltxì, ma kifkey!", BrokenDictionary{}, doubles)
+	line, err := RunLine("Kaltxì, ma kifkey!", BrokenDictionary{})
 
 	assert.Error(t, err)
 	assert.Nil(t, line)
@@ -271,6 +341,14 @@ func TestParseLine(t *testing.T) {
 				LinePart{Raw: "angim", IsWord: true},
 			},
 		},
+		{
+			input: "Kaltx\xff ma",
+			expected: Line{
+				LinePart{Raw: "Kaltx", IsWord: true},
+				LinePart{Raw: "\xff "},
+				LinePart{Raw: "ma", IsWord: true},
+			},
+		},
 	}
 
 	for _, row := range table {
@@ -279,3 +357,16 @@ func TestParseLine(t *testing.T) {
 		})
 	}
 }
+
+func TestLine_RunIn(t *testing.T) {
+	dict := MapDictionary{"getse": {*ParseEntry("kxe.tse: tail")}}
+
+	line, err := ParseLine("Getse!").RunIn(dict, litxaputil.DialectReef)
+	assert.NoError(t, err)
+	assert.Len(t, line[0].Matches, 1)
+	assert.Equal(t, []string{"Ge", "tse"}, line[0].Matches[0].Syllables)
+
+	line, err = ParseLine("Getse!").RunIn(dict, litxaputil.DialectForest)
+	assert.NoError(t, err)
+	assert.Empty(t, line[0].Matches)
+}
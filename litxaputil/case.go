@@ -0,0 +1,108 @@
+package litxaputil
+
+import (
+	"strings"
+)
+
+// Case is an abstract noun case, which CaseSuffixes turns into the suffix that fits the stem.
+type Case int
+
+const (
+	CaseSubjective Case = iota
+	CaseAgentive
+	CasePatientive
+	CaseDative
+	CaseGenitive
+	CaseTopical
+)
+
+var caseNames = []string{"subjective", "agentive", "patientive", "dative", "genitive", "topical"}
+
+// String gives the name of the case, e.g. "agentive".
+func (c Case) String() string {
+	if c < 0 || int(c) >= len(caseNames) {
+		return "unknown"
+	}
+
+	return caseNames[c]
+}
+
+// ParseCase finds the case by its name, e.g. "agentive", or its abbreviation, e.g. "agt" or "gen".
+func ParseCase(name string) (Case, bool) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for i, caseName := range caseNames {
+		if name == caseName || (len(name) >= 3 && strings.HasPrefix(caseName, name)) {
+			return Case(i), true
+		}
+	}
+	if alias, ok := caseAliases[name]; ok {
+		return alias, true
+	}
+
+	return 0, false
+}
+
+var caseAliases = map[string]Case{
+	"subj": CaseSubjective,
+	"agt":  CaseAgentive,
+	"erg":  CaseAgentive,
+	"pat":  CasePatientive,
+	"acc":  CasePatientive,
+	"dat":  CaseDative,
+	"gen":  CaseGenitive,
+	"top":  CaseTopical,
+}
+
+// CaseSuffixes gives the suffixes for the case that fit after the syllables, the most common one first. The choice
+// goes by the last sound: vowels take the short forms (-l, -t, -r, -yä, -ri), consonants the long ones (-ìl, -it,
+// -ur, -ä, -ìri), and diphthongs both. The genitive is the exception, as it takes -ä after -o and -u as well, e.g.
+// kelkuä. The subjective has no suffix, so it gives nil.
+func CaseSuffixes(c Case, syllables []string) []string {
+	forms, ok := caseSuffixTable[c]
+	if !ok || len(syllables) == 0 {
+		return nil
+	}
+
+	last := strings.ToLower(syllables[len(syllables)-1])
+	if c == CaseGenitive && (strings.HasSuffix(last, "o") || strings.HasSuffix(last, "u")) {
+		return append([]string(nil), forms[1]...)
+	}
+
+	switch finalSound(last) {
+	case finalVowel:
+		return append([]string(nil), forms[0]...)
+	case finalDiphthong:
+		return append(append([]string(nil), forms[0]...), forms[1]...)
+	default:
+		return append([]string(nil), forms[1]...)
+	}
+}
+
+// caseSuffixTable has the suffixes after a vowel and after a consonant for each case.
+var caseSuffixTable = map[Case][2][]string{
+	CaseAgentive:   {{"l"}, {"ìl"}},
+	CasePatientive: {{"t", "ti"}, {"it", "ti"}},
+	CaseDative:     {{"r", "ru"}, {"ur"}},
+	CaseGenitive:   {{"yä", "ye"}, {"ä", "e"}},
+	CaseTopical:    {{"ri"}, {"ìri"}},
+}
+
+const (
+	finalConsonant = iota
+	finalVowel
+	finalDiphthong
+)
+
+// finalSound tells if the syllable ends in a vowel, a diphthong or a consonant. Pseudovowels count as consonants.
+func finalSound(syllable string) int {
+	syllable = strings.ToLower(syllable)
+	start, end := FindNucleus(syllable)
+	if start == -1 || end != len(syllable) || strings.HasSuffix(syllable, "ll") || strings.HasSuffix(syllable, "rr") {
+		return finalConsonant
+	}
+	if strings.HasSuffix(syllable, "w") || strings.HasSuffix(syllable, "y") {
+		return finalDiphthong
+	}
+
+	return finalVowel
+}
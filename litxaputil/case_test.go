@@ -0,0 +1,80 @@
+package litxaputil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseSuffixes(t *testing.T) {
+	table := []struct {
+		c        Case
+		word     string
+		expected []string
+	}{
+		{CaseSubjective, "ta.ron", nil},
+		{CaseAgentive, "tu.te", []string{"l"}},
+		{CaseAgentive, "ta.ron", []string{"ìl"}},
+		{CaseAgentive, "kaw", []string{"l", "ìl"}},
+		{CasePatientive, "tu.te", []string{"t", "ti"}},
+		{CasePatientive, "'u", []string{"t", "ti"}},
+		{CasePatientive, "ta.ron", []string{"it", "ti"}},
+		{CaseDative, "tu.te", []string{"r", "ru"}},
+		{CaseDative, "ta.ron", []string{"ur"}},
+		{CaseDative, "krr", []string{"ur"}},
+		{CaseGenitive, "Ey.wa", []string{"yä", "ye"}},
+		{CaseGenitive, "ta.ron", []string{"ä", "e"}},
+		{CaseGenitive, "kel.ku", []string{"ä", "e"}},
+		{CaseGenitive, "tsko", []string{"ä", "e"}},
+		{CaseGenitive, "Lo.Mo", []string{"ä", "e"}},
+		{CaseDative, "kel.ku", []string{"r", "ru"}},
+		{CaseTopical, "ngo.pyu", []string{"ri"}},
+		{CaseTopical, "Ka.Ye.Vi", []string{"ri"}},
+		{CaseTopical, "'a.wak", []string{"ìri"}},
+		{CaseTopical, "", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.c.String()+" "+row.word, func(t *testing.T) {
+			var syllables []string
+			if row.word != "" {
+				syllables = strings.Split(row.word, ".")
+			}
+
+			assert.Equal(t, row.expected, CaseSuffixes(row.c, syllables))
+		})
+	}
+}
+
+func TestParseCase(t *testing.T) {
+	table := []struct {
+		name     string
+		expected Case
+		found    bool
+	}{
+		{"agentive", CaseAgentive, true},
+		{"Patientive", CasePatientive, true},
+		{"gen.", CaseGenitive, true},
+		{"agt", CaseAgentive, true},
+		{"acc", CasePatientive, true},
+		{"top", CaseTopical, true},
+		{"subj", CaseSubjective, true},
+		{"dativ", CaseDative, true},
+		{"ge", CaseSubjective, false},
+		{"vocative", CaseSubjective, false},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			c, found := ParseCase(row.name)
+			assert.Equal(t, row.expected, c)
+			assert.Equal(t, row.found, found)
+		})
+	}
+}
+
+func TestCase_String(t *testing.T) {
+	assert.Equal(t, "dative", CaseDative.String())
+	assert.Equal(t, "unknown", Case(42).String())
+}
@@ -0,0 +1,80 @@
+package litxaputil
+
+import "strings"
+
+// contraction is a rule for how the last syllable of a prefix joins the syllable after it. Both Prefix.Apply and
+// the matcher go by these, so a new contraction only needs a row in the table.
+type contraction struct {
+	// first is the syllable before the boundary.
+	first string
+	// next is what the syllable after the boundary starts with, or "" for any syllable.
+	next string
+	// result replaces first and next. A dot in it splits it into syllables, e.g. "tsu.k" for tsuk + i.nan => tsu.ki.nan.
+	result string
+	// optional contractions are left out by Prefix.Apply, and the matcher accepts the word with or without them.
+	optional bool
+}
+
+// join gives the syllables that first and next become, or false if the rule does not apply to them.
+func (c contraction) join(first, next string) ([]string, bool) {
+	if first != c.first || !strings.HasPrefix(next, c.next) {
+		return nil, false
+	}
+
+	return strings.Split(c.result+next[len(c.next):], "."), true
+}
+
+// contract applies the first contraction that fits the boundary before curr[at], and returns the new syllables and
+// the change in the number of syllables.
+func contract(curr []string, at int) ([]string, int) {
+	if at <= 0 || at >= len(curr) {
+		return curr, 0
+	}
+
+	for _, c := range contractions {
+		if c.optional {
+			continue
+		}
+
+		if joined, ok := c.join(curr[at-1], curr[at]); ok {
+			res := append(curr[:at-1:at-1], joined...)
+			res = append(res, curr[at+1:]...)
+
+			return res, len(joined) - 2
+		}
+	}
+
+	return curr, 0
+}
+
+var contractions = withResyllabification([]contraction{
+	// sä + ka.he.na => ska.he.na
+	{first: "sä", next: "", result: "s", optional: true},
+	// tì + sraw => tsraw
+	{first: "tì", next: "s", result: "ts", optional: true},
+	// si + yu => syu
+	{first: "si", next: "yu", result: "syu", optional: true},
+	// me + 'ey.lan => me + ey.lan => mey.lan
+	{first: "me", next: "e", result: "me"},
+	// pxe + 'ey.lan => pxe + ey.lan => pxey.lan
+	{first: "pxe", next: "e", result: "pxe"},
+}, map[string]string{
+	// ay + o.e => a.yo.e
+	"ay":   "a.y",
+	"pay":  "pa.y",
+	"fay":  "fa.y",
+	"tsay": "tsa.y",
+	// tsuk + i.nan => tsu.ki.nan
+	"tsuk": "tsu.k",
+})
+
+// withResyllabification adds rules for prefixes whose last consonant moves on to a syllable that starts with a vowel.
+func withResyllabification(rules []contraction, prefixes map[string]string) []contraction {
+	for first, result := range prefixes {
+		for _, core := range attachableCores {
+			rules = append(rules, contraction{first: first, next: core, result: result + core})
+		}
+	}
+
+	return rules
+}
@@ -0,0 +1,56 @@
+package litxaputil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContract(t *testing.T) {
+	table := []struct {
+		curr     string
+		at       int
+		expected string
+		delta    int
+	}{
+		{"me.ey.lan", 1, "mey.lan", -1},
+		{"pxe.ey.lan", 1, "pxey.lan", -1},
+		{"ke.tsuk.eyk", 2, "ke.tsu.keyk", 0},
+		{"fay.o.e", 1, "fa.yo.e", 0},
+		{"tsuk.fmong", 1, "tsuk.fmong", 0},
+		{"ay.su.te", 1, "ay.su.te", 0},
+		{"sä.ka.he.na", 1, "sä.ka.he.na", 0}, // Optional, so it's left to the matcher.
+		{"me.ey.lan", 0, "me.ey.lan", 0},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s@%d", row.curr, row.at), func(t *testing.T) {
+			res, delta := contract(strings.Split(row.curr, "."), row.at)
+			assert.Equal(t, row.expected, strings.Join(res, "."))
+			assert.Equal(t, row.delta, delta)
+		})
+	}
+}
+
+func TestContraction_Join(t *testing.T) {
+	table := []struct {
+		contraction contraction
+		first, next string
+		expected    []string
+	}{
+		{contraction{first: "sä", result: "s"}, "sä", "keyn", []string{"skeyn"}},
+		{contraction{first: "tì", next: "s", result: "ts"}, "tì", "sraw", []string{"tsraw"}},
+		{contraction{first: "tì", next: "s", result: "ts"}, "tì", "ran", nil},
+		{contraction{first: "tsuk", next: "i", result: "tsu.ki"}, "tsuk", "i", []string{"tsu", "ki"}},
+	}
+
+	for _, row := range table {
+		t.Run(row.first+"+"+row.next, func(t *testing.T) {
+			res, ok := row.contraction.join(row.first, row.next)
+			assert.Equal(t, row.expected != nil, ok)
+			assert.Equal(t, row.expected, res)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package litxaputil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Dialect is the dialect of Na'vi that words are matched in.
+type Dialect int
+
+const (
+	// DialectAny matches words in either dialect, which is what MatchSyllables does.
+	DialectAny Dialect = iota
+	DialectForest
+	DialectReef
+)
+
+var dialectNames = []string{"any", "forest", "reef"}
+
+// String gives the name of the dialect, e.g. "reef".
+func (d Dialect) String() string {
+	if d < 0 || int(d) >= len(dialectNames) {
+		return "unknown"
+	}
+
+	return dialectNames[d]
+}
+
+// Apply gives the syllables as they're spelled in the dialect. The dictionary is in Forest Na'vi, so only Reef
+// changes anything, and DialectAny gives them back unchanged.
+func (d Dialect) Apply(syllables []string) []string {
+	if d == DialectReef {
+		return ApplyReefEjectives(syllables)
+	}
+
+	return syllables
+}
+
+// variants lists the spellings of the syllables that the dialect accepts, the Forest one first.
+func (d Dialect) variants(syllables []string) [][]string {
+	switch d {
+	case DialectForest:
+		return [][]string{syllables}
+	case DialectReef:
+		return [][]string{ApplyReefEjectives(syllables)}
+	}
+
+	reef := ApplyReefEjectives(syllables)
+	for i := range reef {
+		if reef[i] != syllables[i] {
+			return [][]string{syllables, reef}
+		}
+	}
+
+	return [][]string{syllables}
+}
+
+// ApplyReefEjectives voices the ejectives that start a syllable, the way they're said in Reef Na'vi: px, tx and kx
+// become b, d and g, e.g. kxe.tse => ge.tse and aw.kxìl => aw.gìl. Ejectives at the end of a syllable are kept, as in
+// dukx. It's meant for the syllables after all the affixes are added, so that ejectives that end up at the start of a
+// syllable, like the one in -kxamlä, are voiced too.
+func ApplyReefEjectives(syllables []string) []string {
+	res := append(syllables[:0:0], syllables...)
+	for i, syllable := range res {
+		res[i] = voiceInitialEjective(syllable)
+	}
+
+	return res
+}
+
+// voiceInitialEjective voices the ejective at the start of the syllable, after any space or hyphen, and keeps its case.
+func voiceInitialEjective(syllable string) string {
+	start := len(syllable) - len(strings.TrimLeft(syllable, " -"))
+	rest := syllable[start:]
+
+	for i, ejective := range ejectives {
+		if len(rest) >= len(ejective) && strings.EqualFold(rest[:len(ejective)], ejective) {
+			voiced := voicedEjectives[i]
+			if first, _ := utf8.DecodeRuneInString(rest); unicode.IsUpper(first) {
+				voiced = strings.ToUpper(voiced)
+			}
+
+			return syllable[:start] + voiced + rest[len(ejective):]
+		}
+	}
+
+	return syllable
+}
+
+var ejectives = []string{"px", "tx", "kx"}
+var voicedEjectives = []string{"b", "d", "g"}
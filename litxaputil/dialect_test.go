@@ -0,0 +1,86 @@
+package litxaputil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyReefEjectives(t *testing.T) {
+	table := []struct {
+		syllables string
+		expected  string
+	}{
+		{"kxe.tse", "ge.tse"},
+		{"aw.kxìl", "aw.gìl"},
+		{"txukx", "dukx"},
+		{"tsmu.kan.kxam.lä", "tsmu.kan.gam.lä"},
+		{"Txe.pxa", "De.ba"},
+		{"tì.kan.-kxan", "tì.kan.-gan"},
+		{"u.van. pxi", "u.van. bi"},
+		{"ta.ron", "ta.ron"},
+	}
+
+	for _, row := range table {
+		t.Run(row.syllables, func(t *testing.T) {
+			syllables := strings.Split(row.syllables, ".")
+			res := ApplyReefEjectives(syllables)
+
+			assert.Equal(t, row.expected, strings.Join(res, "."))
+			assert.Equal(t, row.syllables, strings.Join(syllables, "."))
+		})
+	}
+}
+
+func TestDialect_Apply(t *testing.T) {
+	syllables := []string{"kxe", "tse"}
+	assert.Equal(t, []string{"ge", "tse"}, DialectReef.Apply(syllables))
+	assert.Equal(t, syllables, DialectForest.Apply(syllables))
+	assert.Equal(t, syllables, DialectAny.Apply(syllables))
+
+	assert.Equal(t, "reef", DialectReef.String())
+	assert.Equal(t, "unknown", Dialect(7).String())
+}
+
+func TestMatchSyllablesIn(t *testing.T) {
+	table := []struct {
+		word      string
+		syllables string
+		dialect   Dialect
+		expected  string
+	}{
+		{"dukx", "txukx", DialectAny, "dukx"},
+		{"txukx", "txukx", DialectAny, "txukx"},
+		{"dukx", "txukx", DialectReef, "dukx"},
+		{"dùkx", "txukx", DialectReef, "dùkx"},
+		{"txukx", "txukx", DialectReef, ""},
+		{"dukx", "txukx", DialectForest, ""},
+		{"txùkx", "txukx", DialectForest, "txùkx"},
+		{"Tsmukangamlä", "tsmu.kan.kxam.lä", DialectReef, "Tsmu.kan.gam.lä"},
+		{"Tsmukangamlä", "tsmu.kan.kxam.lä", DialectForest, ""},
+	}
+
+	for _, row := range table {
+		t.Run(row.word+" "+row.syllables, func(t *testing.T) {
+			res, stress := MatchSyllablesIn(row.word, strings.Split(row.syllables, "."), 0, 0, row.dialect)
+			if row.expected == "" {
+				assert.Nil(t, res)
+				assert.Equal(t, -1, stress)
+				return
+			}
+
+			assert.Equal(t, row.expected, strings.Join(res, "."))
+			assert.Equal(t, 0, stress)
+		})
+	}
+}
+
+func TestTraceMatch_Reef(t *testing.T) {
+	trace := TraceMatch("awgìl", []string{"aw", "kxìl"}, 0, 0)
+	assert.Equal(t, []string{"aw", "gìl"}, trace.Syllables)
+	assert.Equal(t, []MatchStep{
+		{Expected: []string{"aw"}, Matched: []string{"aw"}},
+		{Expected: []string{"gìl"}, Matched: []string{"gìl"}},
+	}, trace.Steps)
+}
@@ -0,0 +1,47 @@
+package litxaputil
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AffixError is returned by the functions ending in E when an affix, or a field of the entry, can't be applied.
+type AffixError struct {
+	// Kind is "prefix", "infix" or "suffix", or the name of the field that is wrong, e.g. "infixPos".
+	Kind string
+	// Name is the affix or the value of the field.
+	Name string
+	Err  error
+}
+
+func (e *AffixError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+	}
+
+	return fmt.Sprintf("%s %q: %v", e.Kind, e.Name, e.Err)
+}
+
+func (e *AffixError) Unwrap() error {
+	return e.Err
+}
+
+// isSingleSyllable checks if s has exactly one nucleus, which is what an unknown affix has to be to be added as a
+// syllable of its own.
+func isSingleSyllable(s string) bool {
+	_, end := FindNucleus(s)
+	if end == -1 {
+		return false
+	}
+
+	next, _ := FindNucleus(s[end:])
+	return next == -1
+}
+
+var ErrUnknownAffix = errors.New("unknown affix")
+var ErrEmptyWord = errors.New("no syllables to attach to")
+var ErrInvalidPosition = errors.New("position out of range")
+var ErrMissingField = errors.New("missing")
+var ErrInvalidReanalysis = errors.New("invalid reanalysis mode")
+var ErrSlotTaken = errors.New("slot already taken")
+var ErrInfixClash = errors.New("can't be used with")
@@ -0,0 +1,38 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffixError(t *testing.T) {
+	err := error(&AffixError{Kind: "suffix", Name: "blarg", Err: ErrUnknownAffix})
+	assert.EqualError(t, err, `suffix "blarg": unknown affix`)
+	assert.ErrorIs(t, err, ErrUnknownAffix)
+
+	err = &AffixError{Kind: "syllables", Err: ErrEmptyWord}
+	assert.EqualError(t, err, "syllables: no syllables to attach to")
+	assert.ErrorIs(t, err, ErrEmptyWord)
+}
+
+func TestIsSingleSyllable(t *testing.T) {
+	table := []struct {
+		s        string
+		expected bool
+	}{
+		{"hu", true},
+		{"tsyìp", true},
+		{"krr", true},
+		{"kaw", true},
+		{"teri", false},
+		{"l", false},
+		{"", false},
+	}
+
+	for _, row := range table {
+		t.Run(row.s, func(t *testing.T) {
+			assert.Equal(t, row.expected, isSingleSyllable(row.s))
+		})
+	}
+}
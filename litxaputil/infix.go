@@ -1,6 +1,9 @@
 package litxaputil
 
-import "slices"
+import (
+	"fmt"
+	"slices"
+)
 
 func infix(p int, s ...string) Infix {
 	return Infix{Pos: p, SyllableSplit: s}
@@ -41,20 +44,48 @@ func (i Infix) Apply(curr []string, si, pos int) ([]string, int, int) {
 }
 
 func ApplyInfixes(curr []string, infixNames []string, start int, stress int, positions [2][2]int) ([]string, int) {
+	curr, stress, _ = applyInfixes(curr, infixNames, start, stress, positions, false)
+	return curr, stress
+}
+
+// ApplyInfixesE is ApplyInfixes, except that it returns an *AffixError for an unknown infix instead of leaving it
+// out, and for an infix position that is not in the word, or a second position before the first, instead of
+// panicking.
+func ApplyInfixesE(curr []string, infixNames []string, start int, stress int, positions [2][2]int) ([]string, int, error) {
+	return applyInfixes(curr, infixNames, start, stress, positions, true)
+}
+
+func applyInfixes(curr []string, infixNames []string, start int, stress int, positions [2][2]int, strict bool) ([]string, int, error) {
+	if strict {
+		if err := ValidateInfixes(infixNames); err != nil {
+			return nil, -1, err
+		}
+	}
+
 	var infixes [3]*Infix
 	for _, infixName := range infixNames {
 		infix := FindInfix(infixName)
 		if infix != nil {
-			if infixes[infix.Pos] != nil && infix.Pos == 0 {
-				if infixes[infix.Pos].Equal(infixMap["äp"]) && infix.Equal(infixMap["eyk"]) {
-					infix = FindInfix("äpeyk")
-				}
+			if infixes[infix.Pos] != nil && infix.Pos == 0 && isÄpEyk(*infixes[infix.Pos], *infix) {
+				infix = FindInfix("äpeyk")
 			}
 
 			infixes[infix.Pos] = infix
 		}
 	}
 
+	if strict {
+		if (infixes[0] != nil || infixes[1] != nil) && !validInfixPosition(curr, positions[0]) {
+			return nil, -1, &AffixError{Kind: "infixPos", Name: fmt.Sprint(positions[0]), Err: ErrInvalidPosition}
+		}
+		if infixes[2] != nil && !validInfixPosition(curr, positions[1]) {
+			return nil, -1, &AffixError{Kind: "infixPos", Name: fmt.Sprint(positions[1]), Err: ErrInvalidPosition}
+		}
+		if infixes[2] != nil && (infixes[0] != nil || infixes[1] != nil) && positionBefore(positions[1], positions[0]) {
+			return nil, -1, &AffixError{Kind: "infixPos", Name: fmt.Sprint(positions), Err: ErrInvalidPosition}
+		}
+	}
+
 	hasStressShift := stress != start && positions[0] == [2]int{0, 0}
 	allInfixesTogether := positions[1] == positions[0]
 
@@ -124,7 +155,60 @@ func ApplyInfixes(curr []string, infixNames []string, start int, stress int, pos
 		}
 	}
 
-	return curr, stress
+	return curr, stress, nil
+}
+
+// ValidateInfixes checks that the infixes are known, that there's at most one in each slot, and that none of them
+// clash with each other. The only exception to the slots is äp and eyk, which make äpeyk together.
+func ValidateInfixes(infixNames []string) error {
+	var slots [3]string
+	for i, infixName := range infixNames {
+		infix := FindInfix(infixName)
+		if infix == nil {
+			return &AffixError{Kind: "infix", Name: infixName, Err: ErrUnknownAffix}
+		}
+		for _, other := range infixNames[:i] {
+			if slices.Contains(infixClashes[infixName], other) || slices.Contains(infixClashes[other], infixName) {
+				return &AffixError{Kind: "infix", Name: infixName, Err: fmt.Errorf("%w %q", ErrInfixClash, other)}
+			}
+		}
+
+		if other := slots[infix.Pos]; other != "" {
+			if infix.Pos == 0 && isÄpEyk(infixMap[other], *infix) {
+				slots[0] = "äpeyk"
+				continue
+			}
+
+			return &AffixError{Kind: "infix", Name: infixName, Err: fmt.Errorf("%w by %q", ErrSlotTaken, other)}
+		}
+
+		slots[infix.Pos] = infixName
+	}
+
+	return nil
+}
+
+// infixClashes lists the infixes that can't go with each one in other slots. The participles make adjectives, which
+// don't take the affect of slot 2.
+var infixClashes = map[string][]string{
+	"us":  {"ei", "eiy", "eng", "äng", "ats", "uy"},
+	"awn": {"ei", "eiy", "eng", "äng", "ats", "uy"},
+}
+
+// isÄpEyk checks if the two infixes are äp and eyk, in either order.
+func isÄpEyk(a, b Infix) bool {
+	äp, eyk := infixMap["äp"], infixMap["eyk"]
+	return (a.Equal(äp) && b.Equal(eyk)) || (a.Equal(eyk) && b.Equal(äp))
+}
+
+// validInfixPosition checks that the syllable and byte position is in the word.
+func validInfixPosition(curr []string, position [2]int) bool {
+	return position[0] >= 0 && position[0] < len(curr) && position[1] >= 0 && position[1] <= len(curr[position[0]])
+}
+
+// positionBefore checks if the position a comes before b in the word.
+func positionBefore(a, b [2]int) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
 }
 
 func pushInfixPositions(positions [2][2]int, si2 int, pos2 int) [2][2]int {
@@ -138,6 +222,28 @@ func pushInfixPositions(positions [2][2]int, si2 int, pos2 int) [2][2]int {
 	return positions
 }
 
+// VerbInfixes lists the infixes that can go in the slot, 0 to 2: the aspect and mood of slot 0, the tense, aspect,
+// mood and participles of slot 1, and the affect of slot 2. Spelling variants, like eiy for ei, are left out.
+func VerbInfixes(slot int) []string {
+	if slot < 0 || slot >= len(verbInfixes) {
+		return nil
+	}
+
+	return append([]string(nil), verbInfixes[slot]...)
+}
+
+var verbInfixes = [3][]string{
+	{"äp", "eyk", "äpeyk"},
+	{
+		"am", "ìm", "ìy", "ay", "ìsy", "asy",
+		"er", "arm", "ìrm", "ìry", "ary",
+		"ol", "alm", "ìlm", "ìly", "aly",
+		"iv", "irv", "ilv", "imv", "iyev", "ìyev",
+		"us", "awn",
+	},
+	{"ei", "äng", "ats", "uy"},
+}
+
 func FindInfix(name string) *Infix {
 	infix, ok := infixMap[name]
 	if !ok {
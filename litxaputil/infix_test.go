@@ -257,3 +257,87 @@ func TestApplyInfixes(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyInfixesE(t *testing.T) {
+	next, stress, err := ApplyInfixesE([]string{"ta", "ron"}, []string{"us"}, 0, 0, [2][2]int{{0, 1}, {1, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tu", "sa", "ron"}, next)
+	assert.Equal(t, 1, stress)
+
+	_, _, err = ApplyInfixesE([]string{"ta", "ron"}, []string{"glurb"}, 0, 0, [2][2]int{{0, 1}, {1, 1}})
+	assert.ErrorIs(t, err, ErrUnknownAffix)
+	assert.EqualError(t, err, `infix "glurb": unknown affix`)
+
+	_, _, err = ApplyInfixesE([]string{"ta", "ron"}, []string{"ei"}, 0, 0, [2][2]int{{0, 1}, {-1, -1}})
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+
+	_, _, err = ApplyInfixesE([]string{"ta", "ron"}, []string{"us"}, 0, 0, [2][2]int{{0, 5}, {1, 1}})
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+	assert.EqualError(t, err, `infixPos "[0 5]": position out of range`)
+
+	_, _, err = ApplyInfixesE([]string{"tì", "ron", "ì", "kx"}, []string{"ei", "ay"}, 1, 1, [2][2]int{{1, 0}, {0, 2}})
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+	assert.EqualError(t, err, `infixPos "[[1 0] [0 2]]": position out of range`)
+
+	_, _, err = ApplyInfixesE([]string{"ta", "ron"}, []string{"am", "ol"}, 0, 0, [2][2]int{{0, 1}, {1, 1}})
+	assert.ErrorIs(t, err, ErrSlotTaken)
+
+	next, stress, err = ApplyInfixesE([]string{"eyk"}, []string{"eyk", "äp"}, 0, 0, [2][2]int{{0, 0}, {0, 0}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ä", "pey", "keyk"}, next)
+	assert.Equal(t, 2, stress)
+}
+
+func TestValidateInfixes(t *testing.T) {
+	table := []struct {
+		infixes string
+		err     string
+	}{
+		{"", ""},
+		{"us", ""},
+		{"äp,ol,ei", ""},
+		{"äp,eyk", ""},
+		{"eyk,äp,iv", ""},
+		{"äpeyk,iyev,äng", ""},
+		{"am,ol", `infix "ol": slot already taken by "am"`},
+		{"ei,uy", `infix "uy": slot already taken by "ei"`},
+		{"äp,äp", `infix "äp": slot already taken by "äp"`},
+		{"äp,eyk,äp", `infix "äp": slot already taken by "äpeyk"`},
+		{"us,glurb", `infix "glurb": unknown affix`},
+		{"us,ei", `infix "ei": can't be used with "us"`},
+		{"äng,awn", `infix "awn": can't be used with "äng"`},
+		{"äp,awn,eiy", `infix "eiy": can't be used with "awn"`},
+	}
+
+	for _, row := range table {
+		t.Run(row.infixes, func(t *testing.T) {
+			var infixes []string
+			if row.infixes != "" {
+				infixes = strings.Split(row.infixes, ",")
+			}
+
+			err := ValidateInfixes(infixes)
+			if row.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, row.err)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, ValidateInfixes([]string{"am", "ol"}), ErrSlotTaken)
+	assert.ErrorIs(t, ValidateInfixes([]string{"us", "uy"}), ErrInfixClash)
+}
+
+func TestVerbInfixes(t *testing.T) {
+	for slot := 0; slot < 3; slot++ {
+		for _, name := range VerbInfixes(slot) {
+			infix := FindInfix(name)
+			if assert.NotNil(t, infix, name) {
+				assert.Equal(t, slot, infix.Pos, name)
+			}
+		}
+	}
+
+	assert.Nil(t, VerbInfixes(3))
+}
@@ -0,0 +1,74 @@
+package litxaputil
+
+import (
+	"strings"
+)
+
+// SyllablesToIPA writes the syllables in IPA, the way RomanizeIPA reads it: the syllables are separated by dots, and
+// the stressed one is marked with ˈ unless the word only has one syllable. Plosives at the end of a syllable are
+// unreleased, e.g. "kal.ˈt'ɪ" and "tɪ.ˈfmɛ.tok̚".
+func SyllablesToIPA(syllables []string, stress int) string {
+	sb := strings.Builder{}
+	for i, syllable := range syllables {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		if i == stress && len(syllables) > 1 {
+			sb.WriteString("ˈ")
+		}
+
+		sb.WriteString(syllableToIPA(syllable))
+	}
+
+	return sb.String()
+}
+
+func syllableToIPA(syllable string) string {
+	s := strings.ToLower(strings.Trim(syllable, "- "))
+	sb := strings.Builder{}
+
+	for len(s) > 0 {
+		found := false
+		for _, pair := range ipaTable {
+			if strings.HasPrefix(s, pair[0]) {
+				sb.WriteString(pair[1])
+				s = s[len(pair[0]):]
+				found = true
+				break
+			}
+		}
+		if found {
+			// p, t and k are unreleased at the end of a syllable, but not their ejectives.
+			if len(s) == 0 {
+				if last := sb.String(); strings.HasSuffix(last, "p") || strings.HasSuffix(last, "t") || strings.HasSuffix(last, "k") {
+					sb.WriteString("\u031a")
+				}
+			}
+
+			continue
+		}
+
+		// Anything else is written as it is.
+		r := []rune(s)[0]
+		sb.WriteRune(r)
+		s = s[len(string(r)):]
+	}
+
+	return sb.String()
+}
+
+// ipaTable is the romanization table of RomanizeIPA turned around, with the longer spellings first.
+var ipaTable = [][2]string{
+	// Pseudovowels
+	{"rr", "r\u0323"}, {"ll", "l\u0323"},
+	// Digraphs
+	{"ts", "t\u0361s"}, {"tx", "t'"}, {"px", "p'"}, {"kx", "k'"},
+	{"ng", "ŋ"}, {"ch", "tʃ"}, {"sh", "ʃ"},
+	// Vowels
+	{"a", "a"}, {"ä", "æ"}, {"e", "ɛ"}, {"é", "ɛ"}, {"i", "i"}, {"ì", "ɪ"},
+	{"o", "o"}, {"u", "u"}, {"ù", "ʊ"},
+	// Consonants
+	{"'", "ʔ"}, {"’", "ʔ"}, {"r", "ɾ"}, {"y", "j"},
+	{"t", "t"}, {"p", "p"}, {"k", "k"}, {"n", "n"}, {"l", "l"}, {"s", "s"}, {"m", "m"},
+	{"v", "v"}, {"w", "w"}, {"h", "h"}, {"z", "z"}, {"f", "f"}, {"b", "b"}, {"d", "d"}, {"g", "g"},
+}
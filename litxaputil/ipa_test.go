@@ -0,0 +1,53 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyllablesToIPA(t *testing.T) {
+	table := []struct {
+		syllables []string
+		stress    int
+		expected  string
+	}{
+		{[]string{"kal", "txì"}, 1, "kal.ˈt'ɪ"},
+		{[]string{"Tì", "fme", "tok"}, 1, "tɪ.ˈfmɛ.tok̚"},
+		{[]string{"uk", "yom"}, 0, "ˈuk̚.jom"},
+		{[]string{"skxawng"}, 0, "sk'awŋ"},
+		{[]string{"tsam"}, 0, "t͡sam"},
+		{[]string{"krr"}, 0, "kṛ"},
+		{[]string{"sä", "frìp"}, 1, "sæ.ˈfɾɪp̚"},
+		{[]string{"chokx"}, 0, "tʃok'"},
+		{[]string{"'awkx"}, 0, "ʔawk'"},
+		{[]string{"ka", "me", "i", "é"}, 3, "ka.mɛ.i.ˈɛ"},
+		{[]string{"fme", "tok", "-yu"}, -1, "fmɛ.tok̚.ju"},
+	}
+
+	for _, row := range table {
+		t.Run(row.expected, func(t *testing.T) {
+			assert.Equal(t, row.expected, SyllablesToIPA(row.syllables, row.stress))
+		})
+	}
+}
+
+func TestSyllablesToIPA_RoundTrip(t *testing.T) {
+	table := []struct {
+		syllables []string
+		stress    int
+	}{
+		{[]string{"tì", "fme", "tok"}, 1},
+		{[]string{"u", "ran"}, 0},
+		{[]string{"e", "yawr"}, 1},
+		{[]string{"sä", "frìp"}, 1},
+	}
+
+	for _, row := range table {
+		t.Run(SyllablesToIPA(row.syllables, row.stress), func(t *testing.T) {
+			words, stress := RomanizeIPA(SyllablesToIPA(row.syllables, row.stress))
+			assert.Equal(t, [][][]string{{row.syllables}}, words)
+			assert.Equal(t, [][]int{{row.stress}}, stress)
+		})
+	}
+}
@@ -5,40 +5,101 @@ import (
 )
 
 func MatchSyllables(word string, syllables []string, root, stress int) (newSyllables []string, newStress int) {
-	newSyllables, newStress = matchSyllables(word, syllables, root, stress, false)
-	if newSyllables != nil {
-		return
-	}
+	return MatchSyllablesIn(word, syllables, root, stress, DialectAny)
+}
 
-	newSyllables, newStress = matchSyllables(word, syllables, root, stress, true)
-	if newSyllables != nil {
-		return
+// MatchSyllablesIn is MatchSyllables for a word in the dialect. The syllables are in Forest Na'vi, as they come from
+// the dictionary, and are changed to fit the dialect before they're matched.
+func MatchSyllablesIn(word string, syllables []string, root, stress int, dialect Dialect) (newSyllables []string, newStress int) {
+	for _, variant := range dialect.variants(syllables) {
+		for _, allowFuse := range [2]bool{false, true} {
+			newSyllables, newStress = matchSyllables(word, variant, root, stress, allowFuse, nil)
+			if newSyllables != nil {
+				return
+			}
+		}
 	}
 
 	return
 }
 
-func matchSyllables(word string, syllables []string, root, stress int, allowFuse bool) (newSyllables []string, newStress int) {
+// MatchTrace records how MatchSyllables went through a word, for finding out why a word did not match.
+type MatchTrace struct {
+	// Fused is set if the result came from the second pass, where contractions like k.k -> k are allowed.
+	Fused bool
+	// Steps are the matches that were made, in order.
+	Steps []MatchStep
+	// Rest is what was left of the word when the matching stopped, and Remaining is the syllables that were left.
+	// Both are empty if the word matched.
+	Rest      string
+	Remaining []string
+	// Syllables and Stress are the result of MatchSyllables.
+	Syllables []string
+	Stress    int
+}
+
+// MatchStep is one step of the matching, where the Expected syllables were found as Matched in the word.
+type MatchStep struct {
+	Expected []string
+	Matched  []string
+}
+
+// TraceMatch does the same as MatchSyllables, but returns a trace of it. If no pass matched, in either dialect, the
+// trace is from the pass that got the furthest.
+func TraceMatch(word string, syllables []string, root, stress int) MatchTrace {
+	var best *MatchTrace
+	for _, variant := range DialectAny.variants(syllables) {
+		for _, allowFuse := range [2]bool{false, true} {
+			trace := MatchTrace{Fused: allowFuse}
+			trace.Syllables, trace.Stress = matchSyllables(word, variant, root, stress, allowFuse, &trace)
+			if trace.Syllables != nil {
+				return trace
+			}
+
+			if best == nil || len(trace.Rest) < len(best.Rest) {
+				best = &trace
+			}
+		}
+	}
+
+	return *best
+}
+
+func matchSyllables(word string, syllables []string, root, stress int, allowFuse bool, trace *MatchTrace) (newSyllables []string, newStress int) {
 	newSyllables = make([]string, 0, len(syllables))
 	newStress = -1
 	curr := word
 
 	stressOffset := stress
-	rootOffset := root
 
 	for len(syllables) > 0 || len(curr) > 0 {
-		matchedSyllables, next, n, stressPush := nextSyllable(curr, syllables, rootOffset >= 0, allowFuse)
+		// The prefixes that cause lenition have applied it already, so the only lenition left to allow is that of
+		// the short plural, on the first syllable of a word without prefixes.
+		allowLenition := root == 0 && len(newSyllables) == 0
+
+		matchedSyllables, next, n, stressPush := nextSyllable(curr, syllables, allowLenition, allowFuse)
 		if n == 0 {
+			if trace != nil {
+				trace.Rest = curr
+				trace.Remaining = append(syllables[:0:0], syllables...)
+			}
+
 			newSyllables = nil
 			newStress = -1
 			break
 		}
 
+		if trace != nil {
+			trace.Steps = append(trace.Steps, MatchStep{
+				Expected: append(syllables[:0:0], syllables[:n]...),
+				Matched:  append(matchedSyllables[:0:0], matchedSyllables...),
+			})
+		}
+
 		newSyllables = append(newSyllables, matchedSyllables...)
 		syllables = syllables[n:]
 		curr = next
 
-		rootOffset -= n
 		if stressOffset >= 0 {
 			stressOffset -= stressPush
 			if stressOffset <= 0 {
@@ -153,32 +214,22 @@ func nextSyllable(curr string, syllables []string, allowLenition bool, allowFuse
 		return []string{curr[:len(syllable)]}, curr[len(syllable):], 1, 1
 	}
 
-	// Reef Na'vi: gdb (dict entries showing as kx,tx,px)
-	withEjectives, hasInitialEjective := swapInitialEjective(syllables[0])
-	if hasInitialEjective && strings.HasPrefix(currLower, withEjectives) {
-		return []string{curr[:len(withEjectives)]}, curr[len(withEjectives):], 1, 1
-	}
-
 	// Reef Na'vi: ù (dict entries showing as u)
-	for _, syllable := range [2]string{syllables[0], withEjectives} {
-		if syllable := strings.ReplaceAll(syllable, "u", "ù"); syllable != syllables[0] && strings.HasPrefix(currLower, syllable) {
-			return []string{curr[:len(syllable)]}, curr[len(syllable):], 1, 1
-		}
-	}
-
-	// Edge case: contracted sä-X -> sX
-	if len(syllables) >= 2 && syllables[0] == "sä" && strings.HasPrefix(currLower, "s"+syllables[1]) {
-		return []string{curr[:len("s")+len(syllables[1])]}, curr[1+len(syllables[1]):], 2, 2
+	if syllable := strings.ReplaceAll(syllables[0], "u", "ù"); syllable != syllables[0] && strings.HasPrefix(currLower, syllable) {
+		return []string{curr[:len(syllable)]}, curr[len(syllable):], 1, 1
 	}
 
-	// Edge case: contracted tì-sX -> tsX
-	if len(syllables) >= 2 && syllables[0] == "tì" && strings.HasPrefix(currLower, "t"+syllables[1]) {
-		return []string{curr[:len("t")+len(syllables[1])]}, curr[1+len(syllables[1]):], 2, 2
-	}
+	// Optional contractions, e.g. sä.ka -> ska
+	if len(syllables) >= 2 {
+		for _, c := range contractions {
+			if !c.optional {
+				continue
+			}
 
-	// Edge case: contracted si-yu -> syu
-	if len(syllables) >= 2 && syllables[0] == "si" && syllables[1] == "yu" && strings.HasPrefix(currLower, "syu") {
-		return []string{curr[:len("syu")]}, curr[len("syu"):], 2, 2
+			if joined, ok := c.join(syllables[0], syllables[1]); ok && len(joined) == 1 && strings.HasPrefix(currLower, joined[0]) {
+				return []string{curr[:len(joined[0])]}, curr[len(joined[0]):], 2, 2
+			}
+		}
 	}
 
 	// Edge case: po.yä -> pe.yä
@@ -206,18 +257,5 @@ func nextSyllable(curr string, syllables []string, allowLenition bool, allowFuse
 	return nil, curr, 0, 0
 }
 
-func swapInitialEjective(s string) (string, bool) {
-	for i, ejective := range ejectives {
-		if strings.HasPrefix(s, ejective) {
-			return ejectiveAlts[i] + s[len(ejective):], true
-		}
-	}
-
-	return s, false
-}
-
-var ejectives = []string{"px", "tx", "kx"}
-var ejectiveAlts = []string{"b", "d", "g"}
-
 var fusableTails = []string{"px", "tx", "kx", "m", "n", "l", "r", "p", "t", "k"}
 var fusableMids = []string{"a", "ä", "e", "i", "ì", "o", "u", "ù"}
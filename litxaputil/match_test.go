@@ -37,9 +37,21 @@ func TestMatchSyllables(t *testing.T) {
 		{
 			word: "sahilvan", syllables: "tsa.kil.van",
 			root: 1, stress: 2,
+			newSyllables: "",
+			newStress:    -1,
+		},
+		{
+			word: "sahilvan", syllables: "tsa.hil.van",
+			root: 0, stress: 2,
 			newSyllables: "sa.hil.van",
 			newStress:    2,
 		},
+		{
+			word: "fnesìfmetok", syllables: "fne.tì.fme.tok",
+			root: 1, stress: 2,
+			newSyllables: "",
+			newStress:    -1,
+		},
 		{
 			word: "senui", syllables: "sä.nu.i",
 			root: 0, stress: 1,
@@ -64,6 +76,18 @@ func TestMatchSyllables(t *testing.T) {
 			newSyllables: "tsam.syu",
 			newStress:    1,
 		},
+		{
+			word: "tsraw", syllables: "tì.sraw",
+			root: 0, stress: 1,
+			newSyllables: "tsraw",
+			newStress:    0,
+		},
+		{
+			word: "tran", syllables: "tì.ran",
+			root: 0, stress: 1,
+			newSyllables: "",
+			newStress:    -1,
+		},
 		{
 			word: "lehawnga", syllables: "le.hawng",
 			root: 0, stress: 1,
@@ -203,7 +227,7 @@ func TestMatchSyllables(t *testing.T) {
 			newStress:    0,
 		},
 		{
-			word: "ayskxe", syllables: "ay.tskxe",
+			word: "ayskxe", syllables: "ay.skxe",
 			root: 1, stress: 1,
 			newSyllables: "ay.skxe",
 			newStress:    1,
@@ -255,3 +279,70 @@ func TestMatchSyllables(t *testing.T) {
 		})
 	}
 }
+
+func TestTraceMatch(t *testing.T) {
+	table := []struct {
+		word      string
+		syllables string
+		root      int
+		stress    int
+		fused     bool
+		steps     []string
+		rest      string
+		remaining string
+	}{
+		{
+			word: "fmetokyu", syllables: "fme.tok.yu",
+			root: 0, stress: 0,
+			steps: []string{"fme=fme", "tok=tok", "yu=yu"},
+		},
+		{
+			word: "Kameie", syllables: "ka.me.i.e",
+			root: 0, stress: 0,
+			steps: []string{"ka=Ka", "me=me", "i=i", "e=e"},
+		},
+		{
+			word: "tsyìmawnun'i", syllables: "tì.syì.maw.nun.'i",
+			root: 1, stress: 4,
+			steps: []string{"tì.syì=tsyì", "maw=maw", "nun=nun", "'i='i"},
+		},
+		{
+			word: "fmetokari", syllables: "fme.tok.yu",
+			root: 0, stress: 0,
+			steps: []string{"fme=fme", "tok=tok"},
+			rest:  "ari", remaining: "yu",
+		},
+		{
+			word: "tstok", syllables: "fme.tok",
+			root: 0, stress: 0,
+			rest: "tstok", remaining: "fme.tok",
+		},
+		{
+			word: "aka", syllables: "ak.ka",
+			root: 0, stress: 1,
+			fused: true,
+			steps: []string{"ak.ka=a.ka"},
+		},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s %s", row.word, row.syllables), func(t *testing.T) {
+			syllables := strings.Split(row.syllables, ".")
+			trace := TraceMatch(row.word, syllables, row.root, row.stress)
+
+			var steps []string
+			for _, step := range trace.Steps {
+				steps = append(steps, strings.Join(step.Expected, ".")+"="+strings.Join(step.Matched, "."))
+			}
+
+			assert.Equal(t, row.fused, trace.Fused)
+			assert.Equal(t, row.steps, steps)
+			assert.Equal(t, row.rest, trace.Rest)
+			assert.Equal(t, row.remaining, strings.Join(trace.Remaining, "."))
+
+			newSyllables, newStress := MatchSyllables(row.word, syllables, row.root, row.stress)
+			assert.Equal(t, newSyllables, trace.Syllables)
+			assert.Equal(t, newStress, trace.Stress)
+		})
+	}
+}
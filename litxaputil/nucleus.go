@@ -0,0 +1,52 @@
+package litxaputil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FindNucleus finds the byte range of the syllable's nucleus: a vowel, a diphthong (aw, ay, ew, ey) or a
+// pseudovowel (ll, rr). Combining marks after a vowel, as in a decomposed "ì", are included. If there's no nucleus,
+// it returns -1, -1.
+func FindNucleus(syllable string) (int, int) {
+	for i, ch := range syllable {
+		if !strings.ContainsRune(nucleusVowels, unicode.ToLower(ch)) {
+			continue
+		}
+
+		end := skipMarks(syllable, i+utf8.RuneLen(ch))
+		if lower := unicode.ToLower(ch); (lower == 'a' || lower == 'e') && end == i+1 {
+			next, size := utf8.DecodeRuneInString(syllable[end:])
+			if next := unicode.ToLower(next); next == 'w' || next == 'y' {
+				end += size
+			}
+		}
+
+		return i, end
+	}
+
+	lower := strings.ToLower(syllable)
+	for _, pseudovowel := range []string{"ll", "rr"} {
+		if i := strings.Index(lower, pseudovowel); i != -1 {
+			return i, i + len(pseudovowel)
+		}
+	}
+
+	return -1, -1
+}
+
+func skipMarks(s string, pos int) int {
+	for pos < len(s) {
+		ch, size := utf8.DecodeRuneInString(s[pos:])
+		if !unicode.Is(unicode.Mn, ch) {
+			break
+		}
+
+		pos += size
+	}
+
+	return pos
+}
+
+const nucleusVowels = "aäeéiìoóuùáíú"
@@ -0,0 +1,45 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNucleus(t *testing.T) {
+	table := []struct {
+		syllable string
+		nucleus  string
+	}{
+		{"kal", "a"},
+		{"txì", "ì"},
+		{"fme", "e"},
+		{"tskxe", "e"},
+		{"'awn", "aw"},
+		{"keyn", "ey"},
+		{"Tsay", "ay"},
+		{"krr", "rr"},
+		{"kxll", "ll"},
+		{"'RR", "RR"},
+		{"ù", "ù"},
+		{"SÄ", "Ä"},
+		{"tsyi", "i"},
+		{"ti\u0300", "i\u0300"},
+		{"ya\u0308w", "a\u0308"},
+		{"y\u00e4w", "\u00e4"},
+		{"ng", ""},
+		{"", ""},
+	}
+
+	for _, row := range table {
+		t.Run(row.syllable, func(t *testing.T) {
+			start, end := FindNucleus(row.syllable)
+			if row.nucleus == "" {
+				assert.Equal(t, -1, start)
+				assert.Equal(t, -1, end)
+			} else {
+				assert.Equal(t, row.nucleus, row.syllable[start:end])
+			}
+		})
+	}
+}
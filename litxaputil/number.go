@@ -0,0 +1,36 @@
+package litxaputil
+
+// Number is the grammatical number of a noun.
+type Number int
+
+const (
+	NumberSingular Number = iota
+	NumberDual
+	NumberTrial
+	NumberPlural
+	// NumberShortPlural is the plural made by leniting the noun without the ay- prefix, e.g. tute => sute. Only nouns
+	// that can be lenited have it.
+	NumberShortPlural
+)
+
+var numberNames = []string{"singular", "dual", "trial", "plural", "short plural"}
+var numberPrefixes = []string{"", "me", "pxe", "ay", ""}
+
+// String gives the name of the number, e.g. "dual".
+func (n Number) String() string {
+	if n < 0 || int(n) >= len(numberNames) {
+		return "unknown"
+	}
+
+	return numberNames[n]
+}
+
+// Prefix gives the prefix that marks the number, e.g. "me" for the dual. It's empty for the singular and the short
+// plural.
+func (n Number) Prefix() string {
+	if n < 0 || int(n) >= len(numberPrefixes) {
+		return ""
+	}
+
+	return numberPrefixes[n]
+}
@@ -0,0 +1,29 @@
+package litxaputil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumber(t *testing.T) {
+	table := []struct {
+		n      Number
+		name   string
+		prefix string
+	}{
+		{NumberSingular, "singular", ""},
+		{NumberDual, "dual", "me"},
+		{NumberTrial, "trial", "pxe"},
+		{NumberPlural, "plural", "ay"},
+		{NumberShortPlural, "short plural", ""},
+		{Number(-1), "unknown", ""},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			assert.Equal(t, row.name, row.n.String())
+			assert.Equal(t, row.prefix, row.n.Prefix())
+		})
+	}
+}
@@ -1,67 +1,183 @@
 package litxaputil
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
-func prefix(lt string, s ...string) Prefix {
-	return Prefix{loseTail: lt, syllableSplit: s}
+func prefix(name string, s ...string) Prefix {
+	return Prefix{name: name, syllableSplit: s}
+}
+
+// lenitingPrefix is a prefix that causes lenition of the syllable after it, e.g. ay- + tute => ay.su.te.
+func lenitingPrefix(name string, s ...string) Prefix {
+	return Prefix{name: name, syllableSplit: s, lenites: true}
 }
 
 type Prefix struct {
-	// Trigger reanalysis, e.g. awkx + -ìl => aw.kxìl
-	loseTail string
+	// name is the prefix as it's written in Entry.Prefixes, without the hyphen.
+	name string
 	// syllableSplit describes how the prefix will be added.
 	syllableSplit []string
+	// lenites is set for prefixes that cause lenition.
+	lenites bool
+	// stress is how the prefix changes the stress.
+	stress StressRule
 }
 
-func (p Prefix) Apply(curr []string) ([]string, int) {
-	curr = append(p.syllableSplit[:len(p.syllableSplit):len(p.syllableSplit)], curr...)
+// Name is the prefix as it's written in Entry.Prefixes, e.g. "munsna".
+func (p Prefix) Name() string {
+	return p.name
+}
 
-	if p.loseTail != "" {
-		lostTail := false
-		for _, core := range attachableCores {
-			if strings.HasPrefix(curr[len(p.syllableSplit)], core) {
-				lostTail = true
-				curr[len(p.syllableSplit)] = p.loseTail + curr[len(p.syllableSplit)]
-			}
-		}
+// Syllables are the syllables of the prefix before any contraction, e.g. "mun", "sna".
+func (p Prefix) Syllables() []string {
+	return append(p.syllableSplit[:0:0], p.syllableSplit...)
+}
 
-		if !lostTail {
-			curr[len(p.syllableSplit)-1] += p.loseTail
-		}
+// Lenites is true if the prefix causes lenition of the syllable after it.
+func (p Prefix) Lenites() bool {
+	return p.lenites
+}
+
+// Stress is how the prefix changes the stress of the word.
+func (p Prefix) Stress() StressRule {
+	return p.stress
+}
+
+// Apply adds the prefix in front of curr, and joins it with the syllable after it if a contraction calls for it,
+// e.g. tsuk + i.nan => tsu.ki.nan. It returns the new syllables and the number of syllables in front of curr's first
+// one.
+func (p Prefix) Apply(curr []string) ([]string, int) {
+	curr, n, _ := p.apply(curr)
+	return curr, n
+}
+
+// apply is Apply, but it also returns the lenition that happened to curr's first syllable, if any.
+func (p Prefix) apply(curr []string) ([]string, int, string) {
+	lenition := ""
+	if p.lenites && len(curr) > 0 {
+		curr = append(curr[:0:0], curr...)
+		lenition, curr[0] = ApplyLenition(curr[0])
 	}
 
-	return curr, len(p.syllableSplit)
+	curr = append(p.syllableSplit[:len(p.syllableSplit):len(p.syllableSplit)], curr...)
+	curr, delta := contract(curr, len(p.syllableSplit))
+
+	return curr, len(p.syllableSplit) + delta, lenition
 }
 
+// ApplyPrefixes adds the prefixes in front of curr, the innermost one first, and returns the syllables along with
+// the offset of the root.
 func ApplyPrefixes(curr []string, prefixNames []string) ([]string, int) {
+	curr, offset, _, _ := InflectPrefixes(curr, prefixNames, 0)
+	return curr, offset
+}
+
+// InflectPrefixes is ApplyPrefixes, except that it also returns where the stress ends up, and the lenitions that
+// the prefixes caused, e.g. "t→s", in the order they happened. A prefix name with stress marks, e.g. "*fì" or "fì>", moves the stress as
+// they say.
+func InflectPrefixes(curr []string, prefixNames []string, stress int) ([]string, int, int, []string) {
+	curr, offset, stress, lenitions, _ := applyPrefixes(curr, prefixNames, stress, false)
+	return curr, offset, stress, lenitions
+}
+
+// InflectPrefixesE is InflectPrefixes, except that it returns an *AffixError for a prefix that is neither in the
+// inventory nor a single syllable.
+func InflectPrefixesE(curr []string, prefixNames []string, stress int) ([]string, int, int, []string, error) {
+	return applyPrefixes(curr, prefixNames, stress, true)
+}
+
+func applyPrefixes(curr []string, prefixNames []string, stress int, strict bool) ([]string, int, int, []string, error) {
 	totalOffset := 0
+	var lenitions []string
 	for i := len(prefixNames) - 1; i >= 0; i-- {
-		prefixName := prefixNames[i]
+		prefixName, stressRule, stressed := cutStressMarks(prefixNames[i])
+		if _, known := prefixMap[prefixName]; strict && !known && !isSingleSyllable(prefixName) {
+			return nil, 0, -1, nil, &AffixError{Kind: "prefix", Name: prefixNames[i], Err: ErrUnknownAffix}
+		}
+
 		prefix := findPrefix(prefixName)
+		if stressed {
+			prefix.stress = stressRule
+		}
 
-		next, n := prefix.Apply(curr)
+		next, n, lenition := prefix.apply(curr)
 		curr = next
 		totalOffset += n
+		stress = prefix.stress.apply(stress+n, 0, len(curr))
+		if lenition != "" {
+			lenitions = append(lenitions, lenition)
+		}
 	}
 
-	return curr, totalOffset
+	return curr, totalOffset, stress, lenitions, nil
 }
 
+// LookupPrefix finds a prefix in the inventory.
+func LookupPrefix(name string) (Prefix, bool) {
+	prefix, ok := prefixMap[name]
+	return prefix, ok
+}
+
+// ListPrefixes lists the prefixes in the inventory, ordered by name.
+func ListPrefixes() []Prefix {
+	res := make([]Prefix, 0, len(prefixMap))
+	for _, prefix := range prefixMap {
+		res = append(res, prefix)
+	}
+	slices.SortFunc(res, func(a, b Prefix) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return res
+}
+
+// findPrefix finds a prefix in the inventory. Unknown prefixes are taken to be a single syllable.
 func findPrefix(name string) Prefix {
 	if prefix, ok := prefixMap[name]; ok {
 		return prefix
 	}
 
-	return Prefix{loseTail: "", syllableSplit: []string{name}}
+	return prefix(name, name)
 }
 
-// tsuk.fmong -> tsuk.fmong
-// tsuk.inan -> tsu.ki.nan
+var prefixMap = makePrefixMap(
+	// Plural and number
+	lenitingPrefix("me", "me"),
+	lenitingPrefix("pxe", "pxe"),
+	lenitingPrefix("ay", "ay"),
+	// Demonstrative and interrogative
+	prefix("tsa", "tsa"),
+	lenitingPrefix("tsay", "tsay"),
+	prefix("fì", "fì"),
+	lenitingPrefix("fay", "fay"),
+	lenitingPrefix("pe", "pe"),
+	lenitingPrefix("pay", "pay"),
+	// Other noun prefixes
+	lenitingPrefix("fne", "fne"),
+	prefix("sna", "sna"),
+	prefix("munsna", "mun", "sna"),
+	prefix("fra", "fra"),
+	// Derivation
+	prefix("tì", "tì"),
+	prefix("sä", "sä"),
+	prefix("ke", "ke"),
+	prefix("nì", "nì"),
+	// le- and attributive a- end in a vowel, so there's nothing to move on to an adjective that starts with one. The
+	// vowels stay apart, e.g. a + e.an => a.e.an. It's the -a suffix that resyllabifies, see suffixMap.
+	prefix("le", "le"),
+	prefix("a", "a"),
+	// Verb prefixes
+	prefix("tsuk", "tsuk"),
+	prefix("ketsuk", "ke", "tsuk"),
+)
+
+func makePrefixMap(prefixes ...Prefix) map[string]Prefix {
+	res := make(map[string]Prefix, len(prefixes))
+	for _, prefix := range prefixes {
+		res[prefix.name] = prefix
+	}
 
-var prefixMap = map[string]Prefix{
-	"tsuk":   prefix("k", "tsu"),
-	"ketsuk": prefix("k", "ke", "tsu"),
-	"ay":     prefix("y", "a"),
-	"pay":    prefix("y", "pa"),
-	"fay":    prefix("y", "fa"),
+	return res
 }
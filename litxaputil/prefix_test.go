@@ -7,16 +7,17 @@ import (
 	"testing"
 )
 
-func TestApplyPrefixes(t *testing.T) {
+func TestInflectPrefixes(t *testing.T) {
 	table := []struct {
 		curr           string
 		prefixes       string
 		expected       string
 		expectedOffset int
+		lenitions      []string
 	}{
 		{
 			curr: "ta.ron", prefixes: "fne",
-			expected: "fne.ta.ron", expectedOffset: 1,
+			expected: "fne.sa.ron", expectedOffset: 1, lenitions: []string{"t→s"},
 		},
 		{
 			curr: "ha.haw", prefixes: "tsuk",
@@ -32,26 +33,114 @@ func TestApplyPrefixes(t *testing.T) {
 		},
 		{
 			curr: "tì.fme.tok", prefixes: "pe,pxe,fne",
-			expected: "pe.pxe.fne.tì.fme.tok", expectedOffset: 3,
+			expected: "pe.pe.fne.sì.fme.tok", expectedOffset: 3, lenitions: []string{"t→s", "px→p"},
 		},
 		{
 			curr: "tskxe", prefixes: "ay",
-			expected: "ay.tskxe", expectedOffset: 1,
+			expected: "ay.skxe", expectedOffset: 1, lenitions: []string{"ts→s"},
 		},
 		{
 			curr: "o.e", prefixes: "ay",
 			expected: "a.yo.e", expectedOffset: 1,
 		},
+		{
+			curr: "'e.wan", prefixes: "ay",
+			expected: "a.ye.wan", expectedOffset: 1, lenitions: []string{"'e→e"},
+		},
+		{
+			curr: "tu.te", prefixes: "me",
+			expected: "me.su.te", expectedOffset: 1, lenitions: []string{"t→s"},
+		},
+		{
+			curr: "'ey.lan", prefixes: "me",
+			expected: "mey.lan", expectedOffset: 0, lenitions: []string{"'e→e"},
+		},
+		{
+			curr: "'ey.lan", prefixes: "fay",
+			expected: "fa.yey.lan", expectedOffset: 1, lenitions: []string{"'e→e"},
+		},
+		{
+			curr: "ta.ron", prefixes: "munsna",
+			expected: "mun.sna.ta.ron", expectedOffset: 2,
+		},
+		{
+			curr: "tu.te", prefixes: "tì",
+			expected: "tì.tu.te", expectedOffset: 1,
+		},
 	}
 
 	for _, row := range table {
 		t.Run(fmt.Sprintf("%s- %s", row.prefixes, row.curr), func(t *testing.T) {
 			curr := strings.Split(row.curr, ".")
 			prefixes := strings.Split(row.prefixes, ",")
-			next, nextOffset := ApplyPrefixes(curr, prefixes)
+			next, nextOffset, nextStress, lenitions := InflectPrefixes(curr, prefixes, 0)
 
 			assert.Equal(t, row.expected, strings.Join(next, "."))
 			assert.Equal(t, row.expectedOffset, nextOffset)
+			assert.Equal(t, row.expectedOffset, nextStress)
+			assert.Equal(t, row.lenitions, lenitions)
+			assert.Equal(t, row.curr, strings.Join(curr, "."))
+		})
+	}
+}
+
+func TestApplyPrefixes(t *testing.T) {
+	next, offset := ApplyPrefixes([]string{"ta", "ron"}, []string{"ay", "fne"})
+	assert.Equal(t, []string{"ay", "fne", "sa", "ron"}, next)
+	assert.Equal(t, 2, offset)
+}
+
+func TestListPrefixes(t *testing.T) {
+	prefixes := ListPrefixes()
+	names := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		names = append(names, prefix.Name())
+	}
+
+	assert.Equal(t, []string{
+		"a", "ay", "fay", "fne", "fra", "fì", "ke", "ketsuk", "le", "me", "munsna", "nì",
+		"pay", "pe", "pxe", "sna", "sä", "tsa", "tsay", "tsuk", "tì",
+	}, names)
+}
+
+func TestLookupPrefix(t *testing.T) {
+	table := []struct {
+		name      string
+		found     bool
+		syllables []string
+		lenites   bool
+	}{
+		{"munsna", true, []string{"mun", "sna"}, false},
+		{"tsay", true, []string{"tsay"}, true},
+		{"tì", true, []string{"tì"}, false},
+		{"pxe", true, []string{"pxe"}, true},
+		{"pe", true, []string{"pe"}, true},
+		{"kx", false, nil, false},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			prefix, found := LookupPrefix(row.name)
+			assert.Equal(t, row.found, found)
+			if found {
+				assert.Equal(t, row.name, prefix.Name())
+				assert.Equal(t, row.syllables, prefix.Syllables())
+				assert.Equal(t, row.lenites, prefix.Lenites())
+			}
 		})
 	}
 }
+
+func TestInflectPrefixesE(t *testing.T) {
+	next, offset, stress, lenitions, err := InflectPrefixesE([]string{"tu", "te"}, []string{"tì", "kaw"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tì", "kaw", "tu", "te"}, next)
+	assert.Equal(t, 2, offset)
+	assert.Equal(t, 3, stress)
+	assert.Nil(t, lenitions)
+
+	next, _, _, _, err = InflectPrefixesE([]string{"tu", "te"}, []string{"fnetsa"}, 0)
+	assert.Nil(t, next)
+	assert.ErrorIs(t, err, ErrUnknownAffix)
+	assert.EqualError(t, err, `prefix "fnetsa": unknown affix`)
+}
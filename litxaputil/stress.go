@@ -0,0 +1,85 @@
+package litxaputil
+
+import "strings"
+
+// StressKind is what a StressRule does to the stress.
+type StressKind int
+
+const (
+	// StressKeep leaves the stress on the syllable it was on.
+	StressKeep StressKind = iota
+	// StressOnAffix moves the stress to the first syllable of the affix.
+	StressOnAffix
+	// StressMove moves the stress Offset syllables from where it was, towards the end if it's positive.
+	StressMove
+)
+
+// StressRule is how a prefix or suffix changes the stress of the word it's added to. The zero value keeps it.
+type StressRule struct {
+	Kind   StressKind
+	Offset int
+}
+
+// apply gives the new stress of a word of n syllables, where the affix starts at the syllable affixStart and stress
+// is where the stress would be if the affix did not change it.
+func (rule StressRule) apply(stress, affixStart, n int) int {
+	switch rule.Kind {
+	case StressOnAffix:
+		stress = affixStart
+	case StressMove:
+		stress += rule.Offset
+	}
+
+	if stress < 0 {
+		return 0
+	} else if stress >= n {
+		return n - 1
+	}
+
+	return stress
+}
+
+// StressMark in front of an affix name gives the affix the stress, e.g. "*fì" in fì.kem.
+const StressMark = "*"
+
+// StressLater and StressEarlier after an affix name move the stress one syllable towards the end or the start of
+// the word for each mark, e.g. "yu>" or "fì<<".
+const (
+	StressLater   = ">"
+	StressEarlier = "<"
+)
+
+// AffixNames removes the stress marks from the affix names, e.g. "*fì" => "fì", for showing them to the user.
+func AffixNames(names []string) []string {
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		name, _, _ = cutStressMarks(name)
+		res = append(res, name)
+	}
+
+	return res
+}
+
+// cutStressMarks removes the stress marks from an affix name, and gives the rule they stand for. It's false if the
+// name has no marks.
+func cutStressMarks(name string) (string, StressRule, bool) {
+	if rest, ok := strings.CutPrefix(name, StressMark); ok {
+		return rest, StressRule{Kind: StressOnAffix}, true
+	}
+
+	offset := 0
+	for {
+		if rest, ok := strings.CutSuffix(name, StressLater); ok {
+			name, offset = rest, offset+1
+		} else if rest, ok := strings.CutSuffix(name, StressEarlier); ok {
+			name, offset = rest, offset-1
+		} else {
+			break
+		}
+	}
+	if offset != 0 {
+		return name, StressRule{Kind: StressMove, Offset: offset}, true
+	}
+
+	return name, StressRule{}, false
+}
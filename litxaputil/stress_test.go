@@ -0,0 +1,100 @@
+package litxaputil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStressRule_apply(t *testing.T) {
+	table := []struct {
+		rule       StressRule
+		stress     int
+		affixStart int
+		n          int
+		expected   int
+	}{
+		{StressRule{}, 1, 0, 3, 1},
+		{StressRule{Kind: StressOnAffix}, 2, 0, 3, 0},
+		{StressRule{Kind: StressOnAffix}, 0, 2, 4, 2},
+		{StressRule{Kind: StressMove, Offset: 1}, 1, 0, 3, 2},
+		{StressRule{Kind: StressMove, Offset: -1}, 2, 0, 3, 1},
+		{StressRule{Kind: StressMove, Offset: 5}, 1, 0, 3, 2},
+		{StressRule{Kind: StressMove, Offset: -5}, 1, 0, 3, 0},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%v %d", row.rule, row.stress), func(t *testing.T) {
+			assert.Equal(t, row.expected, row.rule.apply(row.stress, row.affixStart, row.n))
+		})
+	}
+}
+
+func TestInflectPrefixes_StressMark(t *testing.T) {
+	table := []struct {
+		curr     string
+		prefixes string
+		stress   int
+		expected string
+		stressed int
+	}{
+		{"kem", "*fì", 0, "fì.kem", 0},
+		{"ta.ron", "*munsna", 1, "mun.sna.ta.ron", 0},
+		{"ta.ron", "*fne", 0, "fne.sa.ron", 0},
+		{"ta.ron", "fne", 0, "fne.sa.ron", 1},
+		{"ta.ron", "fne>", 0, "fne.sa.ron", 2},
+		{"tseng", "fì<", 0, "fì.tseng", 0},
+		{"ta.ron", "tì<<", 1, "tì.ta.ron", 0},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s- %s", row.prefixes, row.curr), func(t *testing.T) {
+			next, _, stress, _ := InflectPrefixes(strings.Split(row.curr, "."), strings.Split(row.prefixes, ","), row.stress)
+			assert.Equal(t, row.expected, strings.Join(next, "."))
+			assert.Equal(t, row.stressed, stress)
+		})
+	}
+
+	_, _, _, _, err := InflectPrefixesE([]string{"kem"}, []string{"*fnetsa"}, 0)
+	assert.EqualError(t, err, `prefix "*fnetsa": unknown affix`)
+}
+
+func TestInflectSuffixes_StressMark(t *testing.T) {
+	table := []struct {
+		curr     string
+		suffixes string
+		stress   int
+		expected string
+		stressed int
+	}{
+		{"ta.ron", "*teri", 0, "ta.ron.te.ri", 2},
+		{"awkx", "*ìl", 0, "aw.kxìl", 1},
+		{"ta.ron", "*yu,teri", 0, "ta.ron.yu.te.ri", 2},
+		{"ta.ron", "yu,teri", 0, "ta.ron.yu.te.ri", 0},
+		{"ta.ron", "yu>", 0, "ta.ron.yu", 1},
+		{"ta.ron", "yu>>,teri<", 0, "ta.ron.yu.te.ri", 1},
+	}
+
+	for _, row := range table {
+		t.Run(fmt.Sprintf("%s -%s", row.curr, row.suffixes), func(t *testing.T) {
+			next, stress := InflectSuffixes(strings.Split(row.curr, "."), strings.Split(row.suffixes, ","), row.stress)
+			assert.Equal(t, row.expected, strings.Join(next, "."))
+			assert.Equal(t, row.stressed, stress)
+
+			next, stress, err := InflectSuffixesE(strings.Split(row.curr, "."), strings.Split(row.suffixes, ","), row.stress)
+			assert.NoError(t, err)
+			assert.Equal(t, row.expected, strings.Join(next, "."))
+			assert.Equal(t, row.stressed, stress)
+		})
+	}
+
+	_, _, err := InflectSuffixesE([]string{"ta", "ron"}, []string{"*teriri"}, 0)
+	assert.EqualError(t, err, `suffix "*teriri": unknown affix`)
+}
+
+func TestAffixNames(t *testing.T) {
+	assert.Equal(t, []string{"fì", "yu", "teri", "tì", "a"}, AffixNames([]string{"*fì", "yu>", "teri<<", "tì", "a"}))
+	assert.Empty(t, AffixNames(nil))
+}
@@ -16,26 +16,38 @@ type Suffix struct {
 	// first: will end the last syllable
 	// non-first: always its own syllable
 	syllableSplit []string
+	// stress is how the suffix changes the stress.
+	stress StressRule
 }
 
 func (suffix Suffix) Apply(curr []string) []string {
+	res, err := suffix.ApplyE(curr)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// ApplyE is Apply, but it returns ErrEmptyWord or ErrInvalidReanalysis instead of panicking.
+func (suffix Suffix) ApplyE(curr []string) ([]string, error) {
 	if len(curr) == 0 {
-		panic("Suffix attempted attached to empty word")
+		return nil, ErrEmptyWord
 	}
 
 	lastSyllable := curr[len(curr)-1]
 
 	switch suffix.reanalysis {
 	case sraNewSyllable:
-		return append(curr, suffix.syllableSplit...)
+		return append(curr, suffix.syllableSplit...), nil
 	case sraAttach:
 		for _, core := range attachableCores {
 			if strings.HasSuffix(lastSyllable, core) {
 				curr[len(curr)-1] = lastSyllable + suffix.syllableSplit[0]
-				return append(curr, suffix.syllableSplit[1:]...)
+				return append(curr, suffix.syllableSplit[1:]...), nil
 			}
 		}
-		return append(curr, suffix.syllableSplit...)
+		return append(curr, suffix.syllableSplit...), nil
 	case sraStealCoda:
 		unStealableCoda := false
 		for _, coda := range unStealableCodas {
@@ -49,26 +61,61 @@ func (suffix Suffix) Apply(curr []string) []string {
 				if strings.HasSuffix(lastSyllable, coda) {
 					curr[len(curr)-1] = lastSyllable[:len(lastSyllable)-len(coda)]
 					curr = append(curr, lastSyllable[len(lastSyllable)-len(coda):]+suffix.syllableSplit[0])
-					return append(curr, suffix.syllableSplit[1:]...)
+					return append(curr, suffix.syllableSplit[1:]...), nil
 				}
 			}
 		}
 
-		return append(curr, suffix.syllableSplit...)
+		return append(curr, suffix.syllableSplit...), nil
 	default:
-		panic(fmt.Sprint("Invalid suffix reanalysis mode: ", suffix.reanalysis))
+		return nil, fmt.Errorf("%w: %d", ErrInvalidReanalysis, suffix.reanalysis)
 	}
 }
 
-// ApplySuffixes applies the suffixes to the syllable set. None of them change stress (yet), so the stress index
-// shall remain the same before and after.
+// ApplySuffixes applies the suffixes to the syllable set.
 func ApplySuffixes(curr []string, suffixNames []string) []string {
-	for _, suffixName := range suffixNames {
+	curr, _ = InflectSuffixes(curr, suffixNames, 0)
+	return curr
+}
+
+// InflectSuffixes is ApplySuffixes, except that it also returns where the stress ends up. Most suffixes leave the
+// stress where it is, but one with stress marks, e.g. "*yu" or "yu<", moves it as they say.
+func InflectSuffixes(curr []string, suffixNames []string, stress int) ([]string, int) {
+	curr, stress, _ = applySuffixes(curr, suffixNames, stress, false)
+	return curr, stress
+}
+
+// InflectSuffixesE is InflectSuffixes, except that it returns an *AffixError for a suffix that is neither known nor
+// a single syllable, or one that can't be applied.
+func InflectSuffixesE(curr []string, suffixNames []string, stress int) ([]string, int, error) {
+	return applySuffixes(curr, suffixNames, stress, true)
+}
+
+func applySuffixes(curr []string, suffixNames []string, stress int, strict bool) ([]string, int, error) {
+	for _, name := range suffixNames {
+		suffixName, stressRule, stressed := cutStressMarks(name)
+		if _, known := suffixMap[suffixName]; strict && !known && !isSingleSyllable(suffixName) {
+			return nil, -1, &AffixError{Kind: "suffix", Name: name, Err: ErrUnknownAffix}
+		}
+
 		suffix := findSuffix(suffixName)
-		curr = suffix.Apply(curr)
+		if stressed {
+			suffix.stress = stressRule
+		}
+		if !strict {
+			curr = suffix.Apply(curr)
+		} else {
+			next, err := suffix.ApplyE(curr)
+			if err != nil {
+				return nil, -1, &AffixError{Kind: "suffix", Name: name, Err: err}
+			}
+			curr = next
+		}
+
+		stress = suffix.stress.apply(stress, len(curr)-len(suffix.syllableSplit), len(curr))
 	}
 
-	return curr
+	return curr, stress, nil
 }
 
 func findSuffix(name string) Suffix {
@@ -121,23 +168,24 @@ var suffixMap = map[string]Suffix{
 	"ftumfa":  suffix(sraNewSyllable, "ftum", "fa"),
 	"ftuopa":  suffix(sraNewSyllable, "ftu", "o", "pa"),
 
+	// Attributive -a takes the last consonant of the adjective, e.g. sìl.tsan + a => sìl.tsa.na, and is a syllable of
+	// its own after a vowel, e.g. ap.xa + a => ap.xa.a.
 	"a": suffix(sraStealCoda, "a"),
 	"o": suffix(sraStealCoda, "o"),
 
-	"l":                suffix(sraAttach, "l"),
-	"ìl":               suffix(sraStealCoda, "ìl"),
-	"t":                suffix(sraAttach, "t"),
-	"ti":               suffix(sraNewSyllable, "ti"),
-	"it":               suffix(sraStealCoda, "it"),
-	"ejectiveReplacer": suffix(sraAttach, "ejectiveReplacer"),
-	"r":                suffix(sraAttach, "r"),
-	"ru":               suffix(sraNewSyllable, "ru"),
-	"ur":               suffix(sraStealCoda, "ur"),
-	"yä":               suffix(sraNewSyllable, "yä"),
-	"ye":               suffix(sraNewSyllable, "ye"),
-	"y":                suffix(sraAttach, "y"),
-	"ä":                suffix(sraStealCoda, "ä"),
-	"e":                suffix(sraStealCoda, "e"),
-	"ri":               suffix(sraNewSyllable, "ri"),
-	"ìri":              suffix(sraStealCoda, "ì", "ri"),
+	"l":   suffix(sraAttach, "l"),
+	"ìl":  suffix(sraStealCoda, "ìl"),
+	"t":   suffix(sraAttach, "t"),
+	"ti":  suffix(sraNewSyllable, "ti"),
+	"it":  suffix(sraStealCoda, "it"),
+	"r":   suffix(sraAttach, "r"),
+	"ru":  suffix(sraNewSyllable, "ru"),
+	"ur":  suffix(sraStealCoda, "ur"),
+	"yä":  suffix(sraNewSyllable, "yä"),
+	"ye":  suffix(sraNewSyllable, "ye"),
+	"y":   suffix(sraAttach, "y"),
+	"ä":   suffix(sraStealCoda, "ä"),
+	"e":   suffix(sraStealCoda, "e"),
+	"ri":  suffix(sraNewSyllable, "ri"),
+	"ìri": suffix(sraStealCoda, "ì", "ri"),
 }
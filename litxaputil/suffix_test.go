@@ -20,7 +20,6 @@ func TestApplySuffixes(t *testing.T) {
 		{"fko", "l", "fkol"},
 		{"mo", "t", "mot"},
 		{"mo", "o", "mo.o"},
-		{"txon", "ejectiveReplacer", "txon.ejectiveReplacer"}, // Ungrammatical, but coverage is coverage.
 		{"tì.fme.tok", "ur", "tì.fme.to.kur"},
 		{"tsam", "o,ti", "tsa.mo.ti"},
 		{"tsa.mo", "ti", "tsa.mo.ti"},
@@ -55,3 +54,23 @@ func TestApplySuffixes_Panic(t *testing.T) {
 	assert.Panics(t, func() { badSuffix.Apply([]string{"stuff"}) })
 	assert.Panics(t, func() { findSuffix("teri").Apply([]string{}) })
 }
+
+func TestInflectSuffixesE(t *testing.T) {
+	next, stress, err := InflectSuffixesE([]string{"ta", "ron"}, []string{"hu", "teri"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ta", "ron", "hu", "te", "ri"}, next)
+	assert.Equal(t, 1, stress)
+
+	next, _, err = InflectSuffixesE([]string{"ta", "ron"}, []string{"teriri"}, 0)
+	assert.Nil(t, next)
+	assert.ErrorIs(t, err, ErrUnknownAffix)
+
+	next, _, err = InflectSuffixesE([]string{}, []string{"teri"}, 0)
+	assert.Nil(t, next)
+	assert.ErrorIs(t, err, ErrEmptyWord)
+	assert.EqualError(t, err, `suffix "teri": no syllables to attach to`)
+
+	next, err = Suffix{reanalysis: -19392, syllableSplit: []string{"blarg"}}.ApplyE([]string{"stuff"})
+	assert.Nil(t, next)
+	assert.ErrorIs(t, err, ErrInvalidReanalysis)
+}
@@ -0,0 +1,360 @@
+// Package lrc reads LRC lyric files, and writes them back out as enhanced LRC with word or syllable timestamps and
+// the stressed syllables flagged.
+package lrc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gissleh/litxap"
+)
+
+// Lyrics is an LRC file.
+type Lyrics struct {
+	Lines []Line
+}
+
+// Line is a line of an LRC file. Lines without timestamps, like the [ar:...] metadata, are kept as they are in Text.
+type Line struct {
+	// Tags are the timestamp tags in front of the text, as they were written.
+	Tags []string
+	// Times are the times of the Tags.
+	Times []time.Duration
+	// Text is the lyric, with any word timestamps taken out.
+	Text string
+	// Marks are the word timestamps that were in the text, if it was already enhanced LRC.
+	Marks []Mark
+	// Result is what litxap made of Text. It's set by Lyrics.Annotate.
+	Result litxap.Line
+}
+
+// Mark is a word timestamp, at a byte position in Line.Text.
+type Mark struct {
+	Pos  int
+	Time time.Duration
+}
+
+// Mode is the granularity of the timestamps written by Write.
+type Mode int
+
+const (
+	WordMode Mode = iota
+	SyllableMode
+)
+
+// Options controls how the enhanced LRC is written.
+type Options struct {
+	Mode Mode
+	// StressMark is written in front of each stressed syllable.
+	StressMark string
+	// LastLineDuration is how long the last line lasts, since there's no next line to end it.
+	LastLineDuration time.Duration
+}
+
+// DefaultOptions writes syllable timestamps and flags stress with the IPA stress mark.
+var DefaultOptions = Options{
+	Mode:             SyllableMode,
+	StressMark:       "ˈ",
+	LastLineDuration: 5 * time.Second,
+}
+
+// Read reads an LRC file. Existing word timestamps are kept as Marks, so they're used again when writing.
+func Read(r io.Reader) (*Lyrics, error) {
+	lyrics := &Lyrics{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lyrics.Lines = append(lyrics.Lines, parseLine(strings.TrimRight(scanner.Text(), "\r")))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lyrics, nil
+}
+
+// Annotate runs every timed line through the dictionary. The stressMark is taken out of the text first, so that
+// lyrics written by Write can be annotated again while keeping their timestamps.
+func (lyrics *Lyrics) Annotate(dict litxap.Dictionary, stressMark string) error {
+	for i := range lyrics.Lines {
+		line := &lyrics.Lines[i]
+		if len(line.Times) == 0 {
+			continue
+		}
+
+		if stressMark != "" {
+			line.removeAll(stressMark)
+		}
+
+		result, err := litxap.RunLine(line.Text, dict)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		line.Result = result
+	}
+
+	return nil
+}
+
+// Write writes the lyrics as enhanced LRC. Metadata lines and line timestamps are written as they were read, while
+// the word or syllable timestamps are spread over the line by syllable count. If the line already had word
+// timestamps, those are kept and only the time between them is spread out.
+func Write(w io.Writer, lyrics *Lyrics, opts Options) error {
+	bw := bufio.NewWriter(w)
+
+	var allTimes []time.Duration
+	for _, line := range lyrics.Lines {
+		allTimes = append(allTimes, line.Times...)
+	}
+	slices.Sort(allTimes)
+
+	for _, line := range lyrics.Lines {
+		if len(line.Times) == 0 {
+			bw.WriteString(line.Text)
+			bw.WriteByte('\n')
+			continue
+		}
+
+		// A line with several timestamps has to be split, as the word timestamps are absolute.
+		for i, start := range line.Times {
+			end := start + opts.LastLineDuration
+			if next, ok := nextTime(allTimes, start); ok {
+				end = next
+			}
+
+			marks := line.Marks
+			if len(line.Times) > 1 {
+				marks = nil
+			}
+
+			bw.WriteString(line.Tags[i])
+			bw.WriteString(line.enhanced(start, end, marks, opts))
+			bw.WriteByte('\n')
+		}
+	}
+
+	return bw.Flush()
+}
+
+type segment struct {
+	pos      int
+	text     string
+	stressed bool
+	weight   int
+}
+
+func (line *Line) enhanced(start, end time.Duration, marks []Mark, opts Options) string {
+	segments := line.segments(opts.Mode)
+	if len(segments) == 0 {
+		return line.Text
+	}
+
+	// A mark at the very end of the text is the end of the line.
+	for _, mark := range marks {
+		if mark.Pos == len(line.Text) && mark.Time > start {
+			end = mark.Time
+		}
+	}
+
+	// Anchor the segments that start where a word timestamp was, then spread the rest by weight between them.
+	times := make([]time.Duration, len(segments)+1)
+	anchored := make([]bool, len(segments)+1)
+	times[0], anchored[0] = start, true
+	times[len(segments)], anchored[len(segments)] = end, true
+	for i, seg := range segments {
+		for _, mark := range marks {
+			if mark.Pos == seg.pos {
+				times[i], anchored[i] = mark.Time, true
+			}
+		}
+	}
+
+	prev := 0
+	for i := 1; i < len(times); i++ {
+		if !anchored[i] {
+			continue
+		}
+
+		total := 0
+		for _, seg := range segments[prev:i] {
+			total += seg.weight
+		}
+
+		acc := 0
+		for j := prev + 1; j < i; j++ {
+			acc += segments[j-1].weight
+			times[j] = times[prev] + (times[i]-times[prev])*time.Duration(acc)/time.Duration(total)
+		}
+
+		prev = i
+	}
+
+	sb := strings.Builder{}
+	for i, seg := range segments {
+		sb.WriteString(formatTag('<', times[i], '>'))
+		if seg.stressed {
+			i := strings.Index(seg.text, "\x00")
+			sb.WriteString(seg.text[:i])
+			sb.WriteString(opts.StressMark)
+			sb.WriteString(seg.text[i+1:])
+		} else {
+			sb.WriteString(seg.text)
+		}
+	}
+	sb.WriteString(formatTag('<', end, '>'))
+
+	return sb.String()
+}
+
+// segments splits the text into the pieces that get a timestamp each. Text that isn't a word is added on to the
+// previous segment, and a zero byte marks where the stress mark should go.
+func (line *Line) segments(mode Mode) []segment {
+	if line.Result == nil {
+		return nil
+	}
+
+	var segments []segment
+	leading := ""
+	pos := 0
+	for i, part := range line.Result.SourceParts(line.Text) {
+		if !line.Result[i].IsWord {
+			if len(segments) > 0 {
+				segments[len(segments)-1].text += part.Text
+			} else {
+				leading += part.Text
+			}
+
+			pos += len(part.Text)
+			continue
+		}
+
+		prefix := part.Prefix()
+		if part.Syllables == nil || mode == WordMode {
+			seg := segment{pos: pos - len(leading), text: leading + part.Text, weight: max(len(part.Syllables), 1)}
+			if part.Stress >= 0 {
+				stressPos := len(leading) + len(prefix)
+				for _, syllable := range part.Syllables[:part.Stress] {
+					stressPos += len(syllable)
+				}
+
+				seg.text = seg.text[:stressPos] + "\x00" + seg.text[stressPos:]
+				seg.stressed = true
+			}
+
+			segments = append(segments, seg)
+		} else {
+			sylPos := pos + len(prefix)
+			for j, syllable := range part.Syllables {
+				seg := segment{pos: sylPos, text: syllable, weight: 1}
+				if j == 0 {
+					seg.pos = pos - len(leading)
+					seg.text = leading + prefix + syllable
+				}
+				if j == part.Stress {
+					seg.text = seg.text[:len(seg.text)-len(syllable)] + "\x00" + syllable
+					seg.stressed = true
+				}
+
+				segments = append(segments, seg)
+				sylPos += len(syllable)
+			}
+		}
+
+		leading = ""
+		pos += len(part.Text)
+	}
+
+	return segments
+}
+
+func (line *Line) removeAll(s string) {
+	for {
+		index := strings.Index(line.Text, s)
+		if index == -1 {
+			return
+		}
+
+		line.Text = line.Text[:index] + line.Text[index+len(s):]
+		for i := range line.Marks {
+			if line.Marks[i].Pos > index {
+				line.Marks[i].Pos -= len(s)
+			}
+		}
+	}
+}
+
+func parseLine(raw string) Line {
+	line := Line{}
+	rest := raw
+
+	for strings.HasPrefix(rest, "[") {
+		end := strings.IndexByte(rest, ']')
+		if end == -1 {
+			break
+		}
+
+		t, ok := parseTime(rest[1:end])
+		if !ok {
+			break
+		}
+
+		line.Tags = append(line.Tags, rest[:end+1])
+		line.Times = append(line.Times, t)
+		rest = rest[end+1:]
+	}
+
+	if len(line.Times) == 0 {
+		return Line{Text: raw}
+	}
+
+	sb := strings.Builder{}
+	pos := 0
+	for _, match := range markRegex.FindAllStringSubmatchIndex(rest, -1) {
+		t, _ := parseTime(rest[match[2]:match[3]])
+
+		sb.WriteString(rest[pos:match[0]])
+		line.Marks = append(line.Marks, Mark{Pos: sb.Len(), Time: t})
+		pos = match[1]
+	}
+	sb.WriteString(rest[pos:])
+	line.Text = sb.String()
+
+	return line
+}
+
+func parseTime(s string) (time.Duration, bool) {
+	if !timeRegex.MatchString(s) {
+		return 0, false
+	}
+
+	minutes, seconds, _ := strings.Cut(s, ":")
+	m, _ := strconv.Atoi(minutes)
+	sec, _ := strconv.ParseFloat(seconds, 64)
+
+	return time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second)).Round(time.Millisecond), true
+}
+
+func formatTag(open byte, t time.Duration, close byte) string {
+	cs := t.Round(10*time.Millisecond) / (10 * time.Millisecond)
+	return fmt.Sprintf("%c%02d:%02d.%02d%c", open, cs/6000, (cs/100)%60, cs%100, close)
+}
+
+func nextTime(sorted []time.Duration, t time.Duration) (time.Duration, bool) {
+	for _, next := range sorted {
+		if next > t {
+			return next, true
+		}
+	}
+
+	return 0, false
+}
+
+var timeRegex = regexp.MustCompile(`^\d+:\d{1,2}(\.\d{1,3})?$`)
+var markRegex = regexp.MustCompile(`<(\d+:\d{1,2}(?:\.\d{1,3})?)>`)
@@ -0,0 +1,117 @@
+package lrc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/stretchr/testify/assert"
+)
+
+var dictionary = testdict.New(testdict.Basic)
+
+const lrcInput = `[ar:Someone]
+[ti:Kaltxì]
+[00:01.00]Kaltxì, ma fmetokyu!
+[00:04.00][00:10.00](Kaltxì)
+[00:07.00]<00:07.00>Ma <00:08.00>tsmukan <00:09.00>kaltxì
+`
+
+func TestRead(t *testing.T) {
+	lyrics, err := Read(strings.NewReader(strings.ReplaceAll(lrcInput, "\n", "\r\n")))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Line{
+		{Text: "[ar:Someone]"},
+		{Text: "[ti:Kaltxì]"},
+		{Tags: []string{"[00:01.00]"}, Times: []time.Duration{time.Second}, Text: "Kaltxì, ma fmetokyu!"},
+		{Tags: []string{"[00:04.00]", "[00:10.00]"}, Times: []time.Duration{4 * time.Second, 10 * time.Second}, Text: "(Kaltxì)"},
+		{Tags: []string{"[00:07.00]"}, Times: []time.Duration{7 * time.Second}, Text: "Ma tsmukan kaltxì", Marks: []Mark{
+			{0, 7 * time.Second}, {3, 8 * time.Second}, {11, 9 * time.Second},
+		}},
+	}, lyrics.Lines)
+}
+
+func TestWrite(t *testing.T) {
+	table := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{
+			name: "Syllables", opts: DefaultOptions,
+			expected: `[ar:Someone]
+[ti:Kaltxì]
+[00:01.00]<00:01.00>Kal<00:01.50>ˈtxì, <00:02.00>ˈma <00:02.50>ˈfme<00:03.00>tok<00:03.50>yu!<00:04.00>
+[00:04.00]<00:04.00>(Kal<00:05.50>ˈtxì)<00:07.00>
+[00:10.00]<00:10.00>(Kal<00:12.50>ˈtxì)<00:15.00>
+[00:07.00]<00:07.00>ˈMa <00:08.00>tsmukan <00:09.00>kal<00:09.50>ˈtxì<00:10.00>
+`,
+		},
+		{
+			name: "Words", opts: Options{Mode: WordMode, StressMark: "*", LastLineDuration: 2 * time.Second},
+			expected: `[ar:Someone]
+[ti:Kaltxì]
+[00:01.00]<00:01.00>Kal*txì, <00:02.00>*ma <00:02.50>*fmetokyu!<00:04.00>
+[00:04.00]<00:04.00>(Kal*txì)<00:07.00>
+[00:10.00]<00:10.00>(Kal*txì)<00:12.00>
+[00:07.00]<00:07.00>*Ma <00:08.00>tsmukan <00:09.00>kal*txì<00:10.00>
+`,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			lyrics, err := Read(strings.NewReader(lrcInput))
+			assert.NoError(t, err)
+			assert.NoError(t, lyrics.Annotate(dictionary, row.opts.StressMark))
+
+			buf := bytes.Buffer{}
+			assert.NoError(t, Write(&buf, lyrics, row.opts))
+			assert.Equal(t, row.expected, buf.String())
+
+			// Reading it back and annotating it again should give the same result.
+			lyrics, err = Read(strings.NewReader(buf.String()))
+			assert.NoError(t, err)
+			assert.NoError(t, lyrics.Annotate(dictionary, row.opts.StressMark))
+
+			buf2 := bytes.Buffer{}
+			assert.NoError(t, Write(&buf2, lyrics, row.opts))
+			assert.Equal(t, row.expected, buf2.String())
+		})
+	}
+}
+
+func TestWrite_NotAnnotated(t *testing.T) {
+	lyrics, err := Read(strings.NewReader("[00:01.00]Kaltxì\n[00:02.00]\n"))
+	assert.NoError(t, err)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, Write(&buf, lyrics, DefaultOptions))
+	assert.Equal(t, "[00:01.00]Kaltxì\n[00:02.00]\n", buf.String())
+}
+
+func TestParseTime(t *testing.T) {
+	table := []struct {
+		input    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"00:01.00", time.Second, true},
+		{"01:02.5", time.Minute + 2500*time.Millisecond, true},
+		{"1:02.345", time.Minute + 2345*time.Millisecond, true},
+		{"12:34", 12*time.Minute + 34*time.Second, true},
+		{"ar:Someone", 0, false},
+		{"offset:+100", 0, false},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			res, ok := parseTime(row.input)
+			assert.Equal(t, row.expected, res)
+			assert.Equal(t, row.ok, ok)
+		})
+	}
+}
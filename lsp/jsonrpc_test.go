@@ -0,0 +1,53 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMessage(t *testing.T) {
+	table := []struct {
+		input  string
+		method string
+		err    error
+	}{
+		{"Content-Length: 40\r\n\r\n{\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"test\"}", "test", nil},
+		{"content-length: 30\r\nContent-Type: utf-8\r\n\r\n{\"jsonrpc\":\"2.0\",\"method\":\"a\"}", "a", nil},
+		{"", "", io.EOF},
+		{"Content-Type: utf-8\r\n\r\n{}", "", ErrInvalidHeader},
+		{"Content-Length: x\r\n\r\n{}", "", ErrInvalidHeader},
+		{"Nonsense\r\n\r\n", "", ErrInvalidHeader},
+		{"Content-Length: 2\r\n\r\n{", "", io.ErrUnexpectedEOF},
+		{"Content-Length: 3\r\n\r\n{x}", "", errParse},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			msg, err := readMessage(bufio.NewReader(strings.NewReader(row.input)))
+			if row.err != nil {
+				assert.ErrorIs(t, err, row.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, row.method, msg.Method)
+		})
+	}
+}
+
+func TestWriteMessage(t *testing.T) {
+	id := json.RawMessage("7")
+	buf := bytes.Buffer{}
+	assert.NoError(t, writeMessage(&buf, &message{ID: &id, Result: json.RawMessage("null")}))
+	assert.Equal(t, "Content-Length: 38\r\n\r\n{\"jsonrpc\":\"2.0\",\"id\":7,\"result\":null}", buf.String())
+
+	msg, err := readMessage(bufio.NewReader(&buf))
+	assert.NoError(t, err)
+	assert.Equal(t, "7", string(*msg.ID))
+}
@@ -0,0 +1,396 @@
+// Package lsp is a Language Server Protocol server for Na'vi text. It shows the stressed syllables and translations
+// on hover, reports unknown words as diagnostics, and highlights stressed syllables with semantic tokens.
+//
+// Documents are synced in full, and positions are counted in UTF-16 code units as the protocol requires.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// Server serves one client over a reader and a writer, usually stdin and stdout.
+type Server struct {
+	dict      litxap.Dictionary
+	documents map[string]*document
+	out       io.Writer
+	shutdown  bool
+}
+
+// New creates a server for the dictionary.
+func New(dict litxap.Dictionary) *Server {
+	return &Server{
+		dict:      dict,
+		documents: make(map[string]*document),
+	}
+}
+
+// Serve reads messages from r and writes the responses and notifications to w. It returns nil when the client sends
+// exit or closes r, and an error if the messages could not be read or written.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	br := bufio.NewReader(r)
+	s.out = w
+
+	for {
+		msg, err := readMessage(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if errors.Is(err, errParse) {
+			if err := s.respondError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// The token types given in the semantic tokens legend. Stressed syllables are keywords, as that is the type every
+// editor theme has a color for.
+var tokenTypes = []string{"keyword"}
+
+func (s *Server) handle(msg *message) error {
+	if s.shutdown && msg.ID != nil {
+		return s.respondError(msg.ID, codeInvalidRequest, "server is shutting down")
+	}
+
+	switch msg.Method {
+	case "initialize":
+		return s.respond(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": 1,
+				"hoverProvider":    true,
+				"semanticTokensProvider": map[string]any{
+					"legend": map[string]any{"tokenTypes": tokenTypes, "tokenModifiers": []string{}},
+					"full":   true,
+				},
+			},
+			"serverInfo": map[string]any{"name": "litxap"},
+		})
+	case "shutdown":
+		s.shutdown = true
+		return s.respond(msg.ID, nil)
+	case "textDocument/didOpen":
+		var params struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+
+		return s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params struct {
+			TextDocument   textDocumentIdentifier `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params.ContentChanges) == 0 {
+			return nil
+		}
+
+		// With full sync, the last change has the whole text.
+		return s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		var params struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+
+		delete(s.documents, params.TextDocument.URI)
+		return s.publishDiagnostics(params.TextDocument.URI, []diagnostic{})
+	case "textDocument/hover":
+		var params struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+			Position     position               `json:"position"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.respondError(msg.ID, codeInvalidParams, err.Error())
+		}
+
+		doc := s.documents[params.TextDocument.URI]
+		if doc == nil {
+			return s.respond(msg.ID, nil)
+		}
+
+		if res := doc.hover(params.Position); res != nil {
+			return s.respond(msg.ID, res)
+		}
+
+		return s.respond(msg.ID, nil)
+	case "textDocument/semanticTokens/full":
+		var params struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.respondError(msg.ID, codeInvalidParams, err.Error())
+		}
+
+		data := []int{}
+		if doc := s.documents[params.TextDocument.URI]; doc != nil {
+			data = doc.semanticTokens()
+		}
+
+		return s.respond(msg.ID, map[string]any{"data": data})
+	}
+
+	// Unknown notifications, like initialized and $/cancelRequest, are ignored. Requests must get an answer.
+	if msg.ID != nil {
+		return s.respondError(msg.ID, codeMethodNotFound, "method not found: "+msg.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) update(uri, text string) error {
+	doc := analyze(text, s.dict)
+	s.documents[uri] = doc
+
+	return s.publishDiagnostics(uri, doc.diagnostics())
+}
+
+func (s *Server) publishDiagnostics(uri string, diagnostics []diagnostic) error {
+	params, err := json.Marshal(map[string]any{"uri": uri, "diagnostics": diagnostics})
+	if err != nil {
+		return err
+	}
+
+	return writeMessage(s.out, &message{Method: "textDocument/publishDiagnostics", Params: params})
+}
+
+func (s *Server) respond(id *json.RawMessage, result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return s.respondError(id, codeInternalError, err.Error())
+	}
+
+	return writeMessage(s.out, &message{ID: nullID(id), Result: data})
+}
+
+func (s *Server) respondError(id *json.RawMessage, code int, msg string) error {
+	return writeMessage(s.out, &message{ID: nullID(id), Error: &responseError{Code: code, Message: msg}})
+}
+
+// nullID gives the ID as is, or a JSON null, since a response must have an ID even if the request's was unreadable.
+func nullID(id *json.RawMessage) *json.RawMessage {
+	if id == nil {
+		null := json.RawMessage("null")
+		return &null
+	}
+
+	return id
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type diagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+const (
+	severityError   = 1
+	severityWarning = 2
+)
+
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    lspRange      `json:"range"`
+}
+
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// document is an analyzed text document.
+type document struct {
+	lines []documentLine
+}
+
+type documentLine struct {
+	line  litxap.Line
+	parts []documentPart
+	err   error
+}
+
+// documentPart is a part of a line with its position, where start and end are UTF-16 columns.
+type documentPart struct {
+	litxap.SourcePart
+	start, end int
+}
+
+func analyze(text string, dict litxap.Dictionary) *document {
+	doc := &document{}
+
+	for _, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimSuffix(raw, "\r")
+
+		line, err := litxap.RunLine(raw, dict)
+		if err != nil {
+			doc.lines = append(doc.lines, documentLine{err: err})
+			continue
+		}
+
+		parts := make([]documentPart, 0, len(line))
+		col := 0
+		for _, part := range line.SourceParts(raw) {
+			n := utf16Len(part.Text)
+			parts = append(parts, documentPart{SourcePart: part, start: col, end: col + n})
+			col += n
+		}
+
+		doc.lines = append(doc.lines, documentLine{line: line, parts: parts})
+	}
+
+	return doc
+}
+
+func (doc *document) diagnostics() []diagnostic {
+	res := make([]diagnostic, 0, 4)
+	for i, line := range doc.lines {
+		if line.err != nil {
+			res = append(res, diagnostic{
+				Range:    lspRange{Start: position{Line: i}, End: position{Line: i + 1}},
+				Severity: severityError,
+				Source:   "litxap",
+				Message:  line.err.Error(),
+			})
+			continue
+		}
+
+		for j, part := range line.parts {
+			linePart := line.line[j]
+			if !linePart.IsWord || len(linePart.Matches) > 0 {
+				continue
+			}
+
+			res = append(res, diagnostic{
+				Range:    lspRange{Start: position{i, part.start}, End: position{i, part.end}},
+				Severity: severityWarning,
+				Source:   "litxap",
+				Message:  fmt.Sprintf("unknown word: %s", linePart.Raw),
+			})
+		}
+	}
+
+	return res
+}
+
+// hover lists the matches of the word at pos, with the stressed syllable in bold and the translation.
+func (doc *document) hover(pos position) *hover {
+	if pos.Line < 0 || pos.Line >= len(doc.lines) {
+		return nil
+	}
+
+	line := doc.lines[pos.Line]
+	for i, part := range line.parts {
+		linePart := line.line[i]
+		if !linePart.IsWord || pos.Character < part.start || pos.Character >= part.end {
+			continue
+		}
+
+		sb := strings.Builder{}
+		if len(linePart.Matches) == 0 {
+			sb.WriteString("unknown word")
+		}
+		for j, match := range linePart.Matches {
+			if j > 0 {
+				sb.WriteString("\n\n")
+			}
+
+			sb.WriteString(litxap.WrapStress("**", "**")(match.Syllables, match.Stress))
+			sb.WriteString(" (")
+			sb.WriteString(litxap.DotSyllables(match.Syllables, match.Stress))
+			sb.WriteString(")")
+			if match.Entry.Translation != "" {
+				sb.WriteString(": ")
+				sb.WriteString(match.Entry.Translation)
+			}
+		}
+
+		return &hover{
+			Contents: markupContent{Kind: "markdown", Value: sb.String()},
+			Range:    lspRange{Start: position{pos.Line, part.start}, End: position{pos.Line, part.end}},
+		}
+	}
+
+	return nil
+}
+
+// semanticTokens encodes a keyword token for every stressed syllable, as relative positions in groups of five.
+func (doc *document) semanticTokens() []int {
+	data := make([]int, 0, 64)
+	prevLine, prevCol := 0, 0
+
+	for i, line := range doc.lines {
+		for _, part := range line.parts {
+			if part.Syllables == nil || part.Stress < 0 {
+				continue
+			}
+
+			col := part.start + utf16Len(part.Prefix())
+			for _, syllable := range part.Syllables[:part.Stress] {
+				col += utf16Len(syllable)
+			}
+
+			deltaCol := col
+			if i == prevLine {
+				deltaCol = col - prevCol
+			}
+
+			data = append(data, i-prevLine, deltaCol, utf16Len(part.Syllables[part.Stress]), 0, 0)
+			prevLine, prevCol = i, col
+		}
+	}
+
+	return data
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,212 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDictionary() litxap.MapDictionary {
+	return testdict.New(testdict.Basic, []string{"ta.*ron.yu: : hunter"})
+}
+
+// testClient is a scripted client talking to a server over pipes.
+type testClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *bufio.Reader
+	nextID int
+	done   chan error
+}
+
+func newTestClient(t *testing.T, dict litxap.Dictionary) *testClient {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+
+	c := &testClient{t: t, in: inW, out: bufio.NewReader(outR), done: make(chan error, 1)}
+	go func() {
+		err := New(dict).Serve(inR, outW)
+		_ = outW.Close()
+		c.done <- err
+	}()
+
+	return c
+}
+
+func (c *testClient) send(id *json.RawMessage, method string, params any) {
+	data, err := json.Marshal(params)
+	assert.NoError(c.t, err)
+	assert.NoError(c.t, writeMessage(c.in, &message{ID: id, Method: method, Params: data}))
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.send(nil, method, params)
+}
+
+// request sends a request and reads messages until its response, skipping any notifications on the way.
+func (c *testClient) request(method string, params any) *message {
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+	c.send(&id, method, params)
+
+	for {
+		msg, err := readMessage(c.out)
+		if !assert.NoError(c.t, err) {
+			return nil
+		}
+
+		if msg.ID == nil {
+			continue
+		}
+
+		assert.Equal(c.t, string(id), string(*msg.ID))
+		return msg
+	}
+}
+
+// diagnostics reads the next notification, which has to be a publishDiagnostics.
+func (c *testClient) diagnostics() []diagnostic {
+	msg, err := readMessage(c.out)
+	assert.NoError(c.t, err)
+	assert.Equal(c.t, "textDocument/publishDiagnostics", msg.Method)
+
+	var params struct {
+		Diagnostics []diagnostic `json:"diagnostics"`
+	}
+	assert.NoError(c.t, json.Unmarshal(msg.Params, &params))
+
+	return params.Diagnostics
+}
+
+func (c *testClient) exit() {
+	c.notify("exit", nil)
+	assert.NoError(c.t, <-c.done)
+}
+
+func unmarshal[T any](t *testing.T, msg *message) T {
+	var res T
+	assert.Nil(t, msg.Error)
+	assert.NoError(t, json.Unmarshal(msg.Result, &res))
+
+	return res
+}
+
+func TestServer(t *testing.T) {
+	c := newTestClient(t, testDictionary())
+
+	init := unmarshal[map[string]any](t, c.request("initialize", map[string]any{"capabilities": map[string]any{}}))
+	capabilities := init["capabilities"].(map[string]any)
+	assert.Equal(t, 1.0, capabilities["textDocumentSync"])
+	assert.Equal(t, true, capabilities["hoverProvider"])
+	c.notify("initialized", map[string]any{})
+
+	uri := "file:///tmp/test.txt"
+	c.notify("textDocument/didOpen", map[string]any{"textDocument": map[string]any{
+		"uri": uri, "languageId": "plaintext", "version": 1,
+		"text": "Kaltxì, ma 𝄞 taronyu!\r\nma tsmukan\n",
+	}})
+	assert.Equal(t, []diagnostic{{
+		Range:    lspRange{Start: position{1, 3}, End: position{1, 10}},
+		Severity: severityWarning,
+		Source:   "litxap",
+		Message:  "unknown word: tsmukan",
+	}}, c.diagnostics())
+
+	type hoverResult struct {
+		Contents markupContent `json:"contents"`
+		Range    lspRange      `json:"range"`
+	}
+
+	// The clef takes two UTF-16 code units, so taronyu starts at 14.
+	res := unmarshal[hoverResult](t, c.request("textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": 0, "character": 16},
+	}))
+	assert.Equal(t, "ta**ron**yu (ta.*ron.yu): hunter", res.Contents.Value)
+	assert.Equal(t, lspRange{Start: position{0, 14}, End: position{0, 21}}, res.Range)
+
+	res = unmarshal[hoverResult](t, c.request("textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": 1, "character": 3},
+	}))
+	assert.Equal(t, "unknown word", res.Contents.Value)
+
+	msg := c.request("textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": 0, "character": 6},
+	})
+	assert.Equal(t, "null", string(msg.Result))
+
+	tokens := unmarshal[map[string][]int](t, c.request("textDocument/semanticTokens/full", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+	}))
+	assert.Equal(t, []int{
+		0, 3, 3, 0, 0, // txì
+		0, 5, 2, 0, 0, // ma
+		0, 8, 3, 0, 0, // ron
+		1, 0, 2, 0, 0, // ma
+	}, tokens["data"])
+
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": "ma fmetokyu"}},
+	})
+	assert.Empty(t, c.diagnostics())
+
+	tokens = unmarshal[map[string][]int](t, c.request("textDocument/semanticTokens/full", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+	}))
+	assert.Equal(t, []int{0, 0, 2, 0, 0, 0, 3, 3, 0, 0}, tokens["data"])
+
+	c.notify("textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": uri}})
+	assert.Empty(t, c.diagnostics())
+
+	msg = c.request("textDocument/definition", map[string]any{})
+	assert.Equal(t, codeMethodNotFound, msg.Error.Code)
+
+	msg = c.request("shutdown", nil)
+	assert.Equal(t, "null", string(msg.Result))
+	assert.Nil(t, msg.Error)
+
+	msg = c.request("textDocument/hover", map[string]any{})
+	assert.Equal(t, codeInvalidRequest, msg.Error.Code)
+
+	c.exit()
+}
+
+func TestServer_DictionaryError(t *testing.T) {
+	c := newTestClient(t, testdict.Broken{})
+
+	c.notify("textDocument/didOpen", map[string]any{"textDocument": map[string]any{
+		"uri": "file:///a.txt", "text": "kaltxì",
+	}})
+	assert.Equal(t, []diagnostic{{
+		Range:    lspRange{Start: position{0, 0}, End: position{1, 0}},
+		Severity: severityError,
+		Source:   "litxap",
+		Message:  `failed to lookup "kaltxì": 500 something something`,
+	}}, c.diagnostics())
+
+	c.exit()
+}
+
+func TestServer_ParseError(t *testing.T) {
+	c := newTestClient(t, testDictionary())
+
+	_, err := io.WriteString(c.in, "Content-Length: 3\r\n\r\n{x}")
+	assert.NoError(t, err)
+
+	msg, err := readMessage(c.out)
+	assert.NoError(t, err)
+	assert.Equal(t, codeParseError, msg.Error.Code)
+	assert.Nil(t, msg.ID, "a null ID is read back as nil")
+
+	assert.NoError(t, c.in.Close())
+	assert.NoError(t, <-c.done)
+}
@@ -0,0 +1,150 @@
+package litxap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MapDictionary is a Dictionary held in memory, with the entries listed under each (lowercase) lookup word.
+type MapDictionary map[string][]Entry
+
+func (md MapDictionary) LookupEntries(word string) ([]Entry, error) {
+	entries, ok := md[strings.ToLower(word)]
+	if !ok || len(entries) == 0 {
+		return nil, ErrEntryNotFound
+	}
+
+	return entries, nil
+}
+
+func (md MapDictionary) LookupMultis(_ string) (LinePartMatch, error) {
+	return LinePartMatch{}, ErrEntryNotFound
+}
+
+// ListEntries lists the entries ordered by their lookup word.
+func (md MapDictionary) ListEntries() ([]Entry, error) {
+	words := make([]string, 0, len(md))
+	for word := range md {
+		words = append(words, word)
+	}
+	slices.Sort(words)
+
+	res := make([]Entry, 0, len(md))
+	for _, word := range words {
+		res = append(res, md[word]...)
+	}
+
+	return res, nil
+}
+
+// Add adds an entry under the lookup word. If the word is empty, the entry's inflected form is used, and the error
+// from Entry.GenerateSyllablesE is returned if it can't be generated.
+func (md MapDictionary) Add(word string, entry Entry) error {
+	if word == "" {
+		syllables, _, _, err := entry.GenerateSyllablesE()
+		if err != nil {
+			return err
+		}
+
+		word = strings.Join(syllables, "")
+	}
+
+	word = strings.ToLower(word)
+	md[word] = append(md[word], entry)
+	return nil
+}
+
+type DictionaryFormat int
+
+const (
+	// DictionaryText has one entry per line in the notation of ParseEntry, optionally after the lookup word and a
+	// tab: "tìtusìranìri\tt·ì.*r·an: tì- <us> -ìri: walk". Blank lines and lines starting with # are skipped.
+	DictionaryText DictionaryFormat = iota
+	// DictionaryJSON is a JSON object with lists of entries under their lookup words.
+	DictionaryJSON
+)
+
+// ReadDictionary reads a dictionary of the given format.
+func ReadDictionary(r io.Reader, format DictionaryFormat) (MapDictionary, error) {
+	md := make(MapDictionary)
+
+	switch format {
+	case DictionaryText:
+		scanner := bufio.NewScanner(r)
+		for lineNo := 1; scanner.Scan(); lineNo++ {
+			line := strings.TrimRight(scanner.Text(), "\r")
+			if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			word, notation, hasWord := strings.Cut(line, "\t")
+			if !hasWord {
+				word, notation = "", line
+			}
+			if strings.TrimSpace(notation) == "" {
+				return nil, fmt.Errorf("%w: line %d has no entry", ErrInvalidDictionary, lineNo)
+			}
+
+			if err := md.Add(strings.TrimSpace(word), *ParseEntry(strings.TrimSpace(notation))); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidDictionary, lineNo, err)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	case DictionaryJSON:
+		var data map[string][]Entry
+		if err := json.NewDecoder(r).Decode(&data); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDictionary, err)
+		}
+
+		for word, entries := range data {
+			for _, entry := range entries {
+				if err := md.Add(word, entry); err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrInvalidDictionary, err)
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownDictionaryFormat, format)
+	}
+
+	return md, nil
+}
+
+// LoadDictionary reads a dictionary file, picking the format by its extension: .json for DictionaryJSON, and
+// .txt or .tsv for DictionaryText.
+func LoadDictionary(path string) (MapDictionary, error) {
+	var format DictionaryFormat
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = DictionaryJSON
+	case ".txt", ".tsv":
+		format = DictionaryText
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDictionaryFormat, path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	md, err := ReadDictionary(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return md, nil
+}
+
+var ErrInvalidDictionary = errors.New("invalid dictionary")
+var ErrUnknownDictionaryFormat = errors.New("unknown dictionary format")
@@ -0,0 +1,125 @@
+package litxap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+)
+
+const textDictionary = `# A small test dictionary
+kal.*txì: : hello
+fme.tok: -yu: tester
+
+Oel	o.e: -l: I
+kameie	k·a.m·e: <ei>: see
+kameie	k··ä: <am,ei>: go
+`
+
+const jsonDictionary = `{
+	"kaltxì": [{"word": "kaltxì", "translation": "hello", "syllables": ["kal", "txì"], "stress": 1}],
+	"Oel": [{"word": "oe", "translation": "I", "syllables": ["o", "e"], "stress": 0, "suffixes": ["l"]}]
+}`
+
+func TestReadDictionary(t *testing.T) {
+	md, err := ReadDictionary(strings.NewReader(textDictionary), DictionaryText)
+	assert.NoError(t, err)
+	assert.Len(t, md, 4)
+
+	entries, err := md.LookupEntries("Kaltxì")
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{*ParseEntry("kal.*txì: : hello")}, entries)
+
+	entries, err = md.LookupEntries("fmetokyu")
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{*ParseEntry("fme.tok: -yu: tester")}, entries)
+
+	entries, err = md.LookupEntries("kameie")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = md.LookupEntries("oel")
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{*ParseEntry("o.e: -l: I")}, entries)
+
+	entries, err = md.LookupEntries("kifkey")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+	assert.Nil(t, entries)
+
+	match, err := md.LookupMultis("kaltxì")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+	assert.Empty(t, match)
+
+	list, err := md.ListEntries()
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		*ParseEntry("fme.tok: -yu: tester"),
+		*ParseEntry("kal.*txì: : hello"),
+		*ParseEntry("k·a.m·e: <ei>: see"),
+		*ParseEntry("k··ä: <am,ei>: go"),
+		*ParseEntry("o.e: -l: I"),
+	}, list)
+}
+
+func TestReadDictionary_JSON(t *testing.T) {
+	md, err := ReadDictionary(strings.NewReader(jsonDictionary), DictionaryJSON)
+	assert.NoError(t, err)
+
+	line, err := RunLine("Kaltxì, oel!", md)
+	assert.NoError(t, err)
+	assert.Equal(t, "Kal<txì>, <o>el!", line.Format("Kaltxì, oel!", WrapStress("<", ">")))
+}
+
+func TestReadDictionary_Fail(t *testing.T) {
+	md, err := ReadDictionary(strings.NewReader("{"), DictionaryJSON)
+	assert.ErrorIs(t, err, ErrInvalidDictionary)
+	assert.Nil(t, md)
+
+	md, err = ReadDictionary(strings.NewReader("kaltxì\t\n"), DictionaryText)
+	assert.ErrorIs(t, err, ErrInvalidDictionary)
+	assert.Nil(t, md)
+
+	md, err = ReadDictionary(strings.NewReader("kal.*txì\nta.ron: fnetsa-\n"), DictionaryText)
+	assert.ErrorIs(t, err, ErrInvalidDictionary)
+	assert.ErrorIs(t, err, litxaputil.ErrUnknownAffix)
+	assert.EqualError(t, err, `invalid dictionary: line 2: prefix "fnetsa": unknown affix`)
+	assert.Nil(t, md)
+
+	md, err = ReadDictionary(strings.NewReader(`{"": [{"word": "blarg"}]}`), DictionaryJSON)
+	assert.ErrorIs(t, err, litxaputil.ErrEmptyWord)
+	assert.Nil(t, md)
+
+	md, err = ReadDictionary(strings.NewReader(""), DictionaryFormat(42))
+	assert.ErrorIs(t, err, ErrUnknownDictionaryFormat)
+	assert.Nil(t, md)
+}
+
+func TestLoadDictionary(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dict.txt"), []byte(textDictionary), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "dict.json"), []byte(jsonDictionary), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte("["), 0644))
+
+	md, err := LoadDictionary(filepath.Join(dir, "dict.txt"))
+	assert.NoError(t, err)
+	assert.Len(t, md, 4)
+
+	md, err = LoadDictionary(filepath.Join(dir, "dict.json"))
+	assert.NoError(t, err)
+	assert.Len(t, md, 2)
+
+	md, err = LoadDictionary(filepath.Join(dir, "bad.json"))
+	assert.ErrorIs(t, err, ErrInvalidDictionary)
+	assert.Nil(t, md)
+
+	md, err = LoadDictionary(filepath.Join(dir, "dict.xml"))
+	assert.ErrorIs(t, err, ErrUnknownDictionaryFormat)
+	assert.Nil(t, md)
+
+	md, err = LoadDictionary(filepath.Join(dir, "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Nil(t, md)
+}
@@ -0,0 +1,74 @@
+package litxap
+
+import "github.com/gissleh/litxap/litxaputil"
+
+// DeclensionRow is a noun in one number, in every case.
+type DeclensionRow struct {
+	Number litxaputil.Number `json:"number"`
+	// Cases has the forms of each case, indexed by litxaputil.Case.
+	Cases [][]CaseForm `json:"cases"`
+}
+
+// Decline makes the table of the noun in every number and case. The number prefixes go inside any prefixes the
+// entry already has, and the short plural is left out if the noun can't be lenited.
+func Decline(entry Entry) ([]DeclensionRow, error) {
+	res := make([]DeclensionRow, 0, 5)
+	for number := litxaputil.NumberSingular; number <= litxaputil.NumberShortPlural; number++ {
+		numbered, ok := withNumber(entry, number)
+		if !ok {
+			continue
+		}
+
+		row := DeclensionRow{Number: number, Cases: make([][]CaseForm, 0, 6)}
+		for c := litxaputil.CaseSubjective; c <= litxaputil.CaseTopical; c++ {
+			forms, err := CaseForms(numbered, c)
+			if err != nil {
+				return nil, err
+			}
+
+			row.Cases = append(row.Cases, forms)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, nil
+}
+
+// withNumber gives the entry in the number, or false if the number is the short plural and the noun can't be
+// lenited.
+func withNumber(entry Entry, number litxaputil.Number) (Entry, bool) {
+	if prefix := number.Prefix(); prefix != "" {
+		entry.Prefixes = append(entry.Prefixes[:len(entry.Prefixes):len(entry.Prefixes)], prefix)
+		return entry, true
+	}
+	if number != litxaputil.NumberShortPlural {
+		return entry, true
+	}
+	if len(entry.Syllables) == 0 {
+		return entry, false
+	}
+
+	// The short plural lenites the first syllable of the word, which is the first prefix if there are any.
+	if len(entry.Prefixes) > 0 {
+		return entry, false
+	}
+
+	lenition, first := litxaputil.ApplyLenition(entry.Syllables[0])
+	if lenition == "" {
+		return entry, false
+	}
+
+	if entry.InfixPos != nil {
+		positions := *entry.InfixPos
+		for i := range positions {
+			if positions[i][0] == 0 {
+				positions[i][1] += len(first) - len(entry.Syllables[0])
+			}
+		}
+		entry.InfixPos = &positions
+	}
+
+	entry.Syllables = append([]string{first}, entry.Syllables[1:]...)
+	return entry, true
+}
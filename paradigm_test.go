@@ -0,0 +1,87 @@
+package litxap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecline(t *testing.T) {
+	table := []struct {
+		entry    string
+		expected []string
+	}{
+		{
+			entry: "tu.te: person",
+			expected: []string{
+				"singular: tu.te tu.tel tu.tet|tu.te.ti tu.ter|tu.te.ru tu.te.yä|tu.te.ye tu.te.ri",
+				"dual: me.*su.te me.*su.tel me.*su.tet|me.*su.te.ti me.*su.ter|me.*su.te.ru me.*su.te.yä|me.*su.te.ye me.*su.te.ri",
+				"trial: pxe.*su.te pxe.*su.tel pxe.*su.tet|pxe.*su.te.ti pxe.*su.ter|pxe.*su.te.ru pxe.*su.te.yä|pxe.*su.te.ye pxe.*su.te.ri",
+				"plural: ay.*su.te ay.*su.tel ay.*su.tet|ay.*su.te.ti ay.*su.ter|ay.*su.te.ru ay.*su.te.yä|ay.*su.te.ye ay.*su.te.ri",
+				"short plural: su.te su.tel su.tet|su.te.ti su.ter|su.te.ru su.te.yä|su.te.ye su.te.ri",
+			},
+		},
+		{
+			entry: "'ek.xin: food",
+			expected: []string{
+				"singular: 'ek.xin 'ek.xi.nìl 'ek.xi.nit|'ek.xin.ti 'ek.xi.nur 'ek.xi.nä|'ek.xi.ne 'ek.xi.nì.ri",
+				"dual: mek.xin mek.xi.nìl mek.xi.nit|mek.xin.ti mek.xi.nur mek.xi.nä|mek.xi.ne mek.xi.nì.ri",
+				"trial: pxek.xin pxek.xi.nìl pxek.xi.nit|pxek.xin.ti pxek.xi.nur pxek.xi.nä|pxek.xi.ne pxek.xi.nì.ri",
+				"plural: a.*yek.xin a.*yek.xi.nìl a.*yek.xi.nit|a.*yek.xin.ti a.*yek.xi.nur a.*yek.xi.nä|a.*yek.xi.ne a.*yek.xi.nì.ri",
+				"short plural: ek.xin ek.xi.nìl ek.xi.nit|ek.xin.ti ek.xi.nur ek.xi.nä|ek.xi.ne ek.xi.nì.ri",
+			},
+		},
+		{
+			entry: "a.*ysä: something",
+			expected: []string{
+				"singular: a.*ysä a.*ysäl a.*ysät|a.*ysä.ti a.*ysär|a.*ysä.ru a.*ysä.yä|a.*ysä.ye a.*ysä.ri",
+				"dual: me.a.*ysä me.a.*ysäl me.a.*ysät|me.a.*ysä.ti me.a.*ysär|me.a.*ysä.ru me.a.*ysä.yä|me.a.*ysä.ye me.a.*ysä.ri",
+				"trial: pxe.a.*ysä pxe.a.*ysäl pxe.a.*ysät|pxe.a.*ysä.ti pxe.a.*ysär|pxe.a.*ysä.ru pxe.a.*ysä.yä|pxe.a.*ysä.ye pxe.a.*ysä.ri",
+				"plural: a.ya.*ysä a.ya.*ysäl a.ya.*ysät|a.ya.*ysä.ti a.ya.*ysär|a.ya.*ysä.ru a.ya.*ysä.yä|a.ya.*ysä.ye a.ya.*ysä.ri",
+			},
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.entry, func(t *testing.T) {
+			rows, err := Decline(*ParseEntry(row.entry))
+			assert.NoError(t, err)
+
+			res := make([]string, 0, len(rows))
+			for _, declension := range rows {
+				cells := make([]string, 0, len(declension.Cases))
+				for _, forms := range declension.Cases {
+					words := make([]string, 0, len(forms))
+					for _, form := range forms {
+						words = append(words, DotSyllables(form.Syllables, form.Stress))
+					}
+					cells = append(cells, strings.Join(words, "|"))
+				}
+				res = append(res, declension.Number.String()+": "+strings.Join(cells, " "))
+			}
+			assert.Equal(t, row.expected, res)
+		})
+	}
+}
+
+func TestDecline_Cells(t *testing.T) {
+	rows, err := Decline(*ParseEntry("tsmu.kan: sibling"))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 5)
+
+	cell := rows[4].Cases[litxaputil.CaseAgentive][0]
+	assert.Equal(t, "smukanìl", cell.Word)
+	assert.Equal(t, []string{"smu", "ka", "nìl"}, cell.Syllables)
+	assert.Equal(t, 0, cell.Stress)
+	assert.Equal(t, []string{"smu", "kan"}, cell.Entry.Syllables)
+	assert.Equal(t, []string{"ìl"}, cell.Entry.Suffixes)
+
+	cell = rows[1].Cases[litxaputil.CaseSubjective][0]
+	assert.Equal(t, "mesmukan", cell.Word)
+	assert.Equal(t, []string{"me"}, cell.Entry.Prefixes)
+
+	_, err = Decline(Entry{Word: "blarg"})
+	assert.ErrorIs(t, err, litxaputil.ErrEmptyWord)
+}
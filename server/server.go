@@ -0,0 +1,215 @@
+// Package server provides an HTTP handler with a JSON API for running lines and words through litxap.
+package server
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/gissleh/litxap"
+)
+
+// Handler serves the JSON API:
+//
+//	POST /line   {"line": "..."}                     -> litxap.LinesEnvelope
+//	POST /lines  {"lines": ["...", ...]}             -> litxap.LinesEnvelope
+//	POST /word   {"word": "...", "entry": {...}}     -> litxap.LinePartMatch
+//	GET  /entry?word=...                             -> {"entries": [...]}
+//
+// GET / serves the web UI, with its files under /ui/. It runs text through /lines and lets the user pick between the
+// readings of ambiguous words.
+//
+// The line endpoints give compact envelopes if ?compact=true is set. Errors are given as {"error": {"code",
+// "message"}}, where a missing entry is "entry_not_found" and a failing dictionary is "dictionary_error".
+type Handler struct {
+	// MaxBodyBytes is the largest request body that will be read.
+	MaxBodyBytes int64
+	// MaxLines is the most lines that can be sent to /lines at once.
+	MaxLines int
+
+	dict litxap.Dictionary
+	mux  *http.ServeMux
+}
+
+// New creates a handler for the dictionary with the default limits of 1 MiB and 1000 lines.
+func New(dict litxap.Dictionary) *Handler {
+	h := &Handler{
+		MaxBodyBytes: 1 << 20,
+		MaxLines:     1000,
+		dict:         dict,
+		mux:          http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("POST /line", h.handleLine)
+	h.mux.HandleFunc("POST /lines", h.handleLines)
+	h.mux.HandleFunc("POST /word", h.handleWord)
+	h.mux.HandleFunc("GET /entry", h.handleEntry)
+	h.mux.HandleFunc("GET /{$}", handleIndex)
+	h.mux.Handle("GET /ui/", http.StripPrefix("/ui/", http.FileServerFS(staticFiles)))
+
+	return h
+}
+
+//go:embed static
+var static embed.FS
+
+var staticFiles = func() fs.FS {
+	sub, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}()
+
+func handleIndex(w http.ResponseWriter, r *http.Request) {
+	http.ServeFileFS(w, r, staticFiles, "index.html")
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Error is the body of every error response.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	CodeBadRequest      = "bad_request"
+	CodeBodyTooLarge    = "body_too_large"
+	CodeTooManyLines    = "too_many_lines"
+	CodeEntryNotFound   = "entry_not_found"
+	CodeNoMatch         = "no_match"
+	CodeDictionaryError = "dictionary_error"
+	CodeInvalidEntry    = "invalid_entry"
+)
+
+type lineRequest struct {
+	Line string `json:"line"`
+}
+
+type linesRequest struct {
+	Lines []string `json:"lines"`
+}
+
+type wordRequest struct {
+	Word  string        `json:"word"`
+	Entry *litxap.Entry `json:"entry"`
+}
+
+type entryResponse struct {
+	Entries []litxap.Entry `json:"entries"`
+}
+
+func (h *Handler) handleLine(w http.ResponseWriter, r *http.Request) {
+	var req lineRequest
+	if !h.readRequest(w, r, &req) {
+		return
+	}
+
+	h.runLines(w, r, []string{req.Line})
+}
+
+func (h *Handler) handleLines(w http.ResponseWriter, r *http.Request) {
+	var req linesRequest
+	if !h.readRequest(w, r, &req) {
+		return
+	}
+	if len(req.Lines) > h.MaxLines {
+		writeError(w, http.StatusRequestEntityTooLarge, CodeTooManyLines, fmt.Sprintf("at most %d lines can be sent at once", h.MaxLines))
+		return
+	}
+
+	h.runLines(w, r, req.Lines)
+}
+
+func (h *Handler) handleWord(w http.ResponseWriter, r *http.Request) {
+	var req wordRequest
+	if !h.readRequest(w, r, &req) {
+		return
+	}
+	if req.Word == "" || req.Entry == nil || len(req.Entry.Syllables) == 0 {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, "word and entry with syllables are required")
+		return
+	}
+
+	syllables, stress, err := litxap.RunWordE(req.Word, *req.Entry)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, CodeInvalidEntry, err.Error())
+		return
+	}
+	if syllables == nil || stress < 0 {
+		writeError(w, http.StatusUnprocessableEntity, CodeNoMatch, fmt.Sprintf("%q does not match the entry", req.Word))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, litxap.LinePartMatch{Syllables: syllables, Stress: stress, Entry: *req.Entry})
+}
+
+func (h *Handler) handleEntry(w http.ResponseWriter, r *http.Request) {
+	word := r.URL.Query().Get("word")
+	if word == "" {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, "the word parameter is required")
+		return
+	}
+
+	entries, err := h.dict.LookupEntries(word)
+	if errors.Is(err, litxap.ErrEntryNotFound) {
+		writeError(w, http.StatusNotFound, CodeEntryNotFound, fmt.Sprintf("%q is not in the dictionary", word))
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadGateway, CodeDictionaryError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entryResponse{Entries: entries})
+}
+
+func (h *Handler) runLines(w http.ResponseWriter, r *http.Request, inputs []string) {
+	lines := make([]litxap.Line, 0, len(inputs))
+	for _, input := range inputs {
+		line, err := litxap.RunLine(input, h.dict)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, CodeDictionaryError, err.Error())
+			return
+		}
+
+		lines = append(lines, line)
+	}
+
+	writeJSON(w, http.StatusOK, litxap.EncodeLines(lines, r.URL.Query().Get("compact") == "true"))
+}
+
+func (h *Handler) readRequest(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		if errors.As(err, new(*http.MaxBytesError)) {
+			writeError(w, http.StatusRequestEntityTooLarge, CodeBodyTooLarge, fmt.Sprintf("the body can be at most %d bytes", h.MaxBodyBytes))
+		} else {
+			writeError(w, http.StatusBadRequest, CodeBadRequest, err.Error())
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, struct {
+		Error Error `json:"error"`
+	}{Error{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
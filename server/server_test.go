@@ -0,0 +1,175 @@
+package server
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap"
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/stretchr/testify/assert"
+)
+
+func doRequest(t *testing.T, h http.Handler, method, target, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var res map[string]any
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	}
+
+	return rec, res
+}
+
+func errorCode(res map[string]any) string {
+	errObj, _ := res["error"].(map[string]any)
+	code, _ := errObj["code"].(string)
+	return code
+}
+
+func TestHandler_Line(t *testing.T) {
+	h := New(testdict.New(testdict.Basic))
+
+	rec, _ := doRequest(t, h, "POST", "/line", `{"line": "Kaltxì, ma fmetokyu!"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var env litxap.LinesEnvelope
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &env))
+	lines, err := env.Decode()
+	assert.NoError(t, err)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, []string{"Kal", "txì"}, lines[0][0].Matches[0].Syllables)
+	assert.Equal(t, 1, lines[0][0].Matches[0].Stress)
+
+	rec, res := doRequest(t, h, "POST", "/line", `{"line": 42}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, CodeBadRequest, errorCode(res))
+
+	rec, res = doRequest(t, h, "POST", "/line", `{"text": "kaltxì"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, CodeBadRequest, errorCode(res))
+
+	rec, _ = doRequest(t, h, "GET", "/line", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandler_Lines(t *testing.T) {
+	h := New(testdict.New(testdict.Basic))
+	h.MaxLines = 2
+
+	rec, _ := doRequest(t, h, "POST", "/lines?compact=true", `{"lines": ["Ma fmetokyu", "ma"]}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var env litxap.LinesEnvelope
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &env))
+	assert.NotNil(t, env.Compact)
+	assert.Len(t, env.Compact.Entries, 2)
+
+	lines, err := env.Decode()
+	assert.NoError(t, err)
+	assert.Len(t, lines, 2)
+
+	rec, res := doRequest(t, h, "POST", "/lines", `{"lines": ["ma", "ma", "ma"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, CodeTooManyLines, errorCode(res))
+}
+
+func TestHandler_Word(t *testing.T) {
+	h := New(testdict.New(testdict.Basic))
+
+	rec, res := doRequest(t, h, "POST", "/word", `{"word": "tìtusìranìri", "entry": {"word": "tìran", "syllables": ["tì", "ran"], "stress": 1, "infixPos": [[0, 1], [1, 1]], "prefixes": ["tì"], "infixes": ["us"], "suffixes": ["ìri"]}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []any{"tì", "tu", "sì", "ra", "nì", "ri"}, res["syllables"])
+	assert.Equal(t, 3.0, res["stress"])
+
+	rec, res = doRequest(t, h, "POST", "/word", `{"word": "kifkey", "entry": {"word": "tìran", "syllables": ["tì", "ran"], "stress": 1}}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, CodeNoMatch, errorCode(res))
+
+	rec, res = doRequest(t, h, "POST", "/word", `{"word": "tìran", "entry": {"word": "tìran", "syllables": ["tì", "ran"], "stress": 1, "infixes": ["us"]}}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, CodeInvalidEntry, errorCode(res))
+
+	rec, res = doRequest(t, h, "POST", "/word", `{"word": "kifkey"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, CodeBadRequest, errorCode(res))
+}
+
+func TestHandler_Entry(t *testing.T) {
+	h := New(testdict.New(testdict.Basic))
+
+	rec, res := doRequest(t, h, "GET", "/entry?word=Kaltx%C3%AC", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, res["entries"], 1)
+
+	rec, res = doRequest(t, h, "GET", "/entry?word=kifkey", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, CodeEntryNotFound, errorCode(res))
+
+	rec, res = doRequest(t, h, "GET", "/entry", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, CodeBadRequest, errorCode(res))
+}
+
+func TestHandler_BrokenDictionary(t *testing.T) {
+	h := New(testdict.Broken{})
+
+	rec, res := doRequest(t, h, "POST", "/line", `{"line": "Kaltxì"}`)
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.Equal(t, CodeDictionaryError, errorCode(res))
+
+	rec, res = doRequest(t, h, "GET", "/entry?word=kaltxì", "")
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.Equal(t, CodeDictionaryError, errorCode(res))
+}
+
+func TestHandler_BodyTooLarge(t *testing.T) {
+	h := New(testdict.New(testdict.Basic))
+	h.MaxBodyBytes = 32
+
+	rec, res := doRequest(t, h, "POST", "/line", `{"line": "`+strings.Repeat("ma ", 20)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, CodeBodyTooLarge, errorCode(res))
+}
+
+func TestHandler_UI(t *testing.T) {
+	h := New(testdict.New(testdict.Basic))
+
+	rec, _ := doRequest(t, h, "GET", "/", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), `<script src="ui/app.js">`)
+
+	for _, name := range []string{"/ui/app.js", "/ui/style.css"} {
+		rec, _ = doRequest(t, h, "GET", name, "")
+		assert.Equal(t, http.StatusOK, rec.Code, name)
+	}
+
+	rec, _ = doRequest(t, h, "GET", "/ui/missing.js", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec, _ = doRequest(t, h, "GET", "/index.html", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// The UI has to work offline, so nothing may be loaded from elsewhere.
+	err := fs.WalkDir(staticFiles, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(staticFiles, path)
+		assert.NotContains(t, string(data), "http://", path)
+		assert.NotContains(t, string(data), "https://", path)
+		assert.NotContains(t, string(data), `src="//`, path)
+
+		return err
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,287 @@
+// The UI for the litxap server. It sends the text to /lines, and keeps the result along with the reading the user
+// picked for every ambiguous word, so that the copied text is exactly the text that was pasted plus the stress marks.
+"use strict";
+
+const input = document.getElementById("input");
+const output = document.getElementById("output");
+const status = document.getElementById("status");
+const markSelect = document.getElementById("mark");
+const copyText = document.getElementById("copy-text");
+const copyHTML = document.getElementById("copy-html");
+
+// Each line is a list of segments: {text} for what isn't a word, and {prefix, raw, readings, chosen} for words.
+let lines = [];
+
+document.getElementById("run").addEventListener("click", run);
+input.addEventListener("keydown", (event) => {
+  if (event.key === "Enter" && (event.ctrlKey || event.metaKey)) {
+    event.preventDefault();
+    run();
+  }
+});
+copyText.addEventListener("click", () => copy(false));
+copyHTML.addEventListener("click", () => copy(true));
+
+async function run() {
+  const texts = input.value.split(/\r?\n/);
+  setStatus("Working...");
+
+  let response;
+  try {
+    response = await fetch("lines", {
+      method: "POST",
+      headers: {"Content-Type": "application/json"},
+      body: JSON.stringify({lines: texts}),
+    });
+  } catch (err) {
+    setStatus("Could not reach the server: " + err.message, true);
+    return;
+  }
+
+  const body = await response.json().catch(() => null);
+  if (!response.ok || body === null) {
+    setStatus(body && body.error ? body.error.message : "The server answered with " + response.status, true);
+    return;
+  }
+
+  lines = body.lines.map((line, i) => segments(texts[i], line));
+  render();
+
+  const ambiguous = lines.flat().filter((seg) => seg.readings && seg.readings.length > 1).length;
+  const unknown = lines.flat().filter((seg) => seg.readings && seg.readings.length === 0).length;
+  setStatus(`${unknown} unknown, ${ambiguous} to choose between.`);
+  copyText.disabled = copyHTML.disabled = false;
+}
+
+// segments pairs the parts of the line with the text they came from. Like Line.Sources in Go, it counts code points,
+// since the server normalizes apostrophes in Raw and leaves out the "lookup|" of an override.
+function segments(text, parts) {
+  const chars = Array.from(text);
+  const res = [];
+  let pos = 0;
+
+  for (const part of parts) {
+    let n = Array.from(part.raw).length;
+    if (part.lookup) {
+      n += Array.from(part.lookup).length + 1;
+    }
+
+    const source = chars.slice(pos, pos + n);
+    pos += n;
+    if (!part.isWord) {
+      res.push({text: source.join("")});
+      continue;
+    }
+
+    const rawLength = Array.from(part.raw).length;
+    const prefix = source.slice(0, source.length - rawLength).join("");
+    const raw = source.slice(source.length - rawLength);
+
+    res.push({prefix, raw: raw.join(""), readings: readings(raw, part.raw, part.matches || []), chosen: -1});
+  }
+
+  return res;
+}
+
+// readings groups the matches by syllables and stress, with the syllables cut from the source text.
+function readings(raw, normalized, matches) {
+  const res = [];
+  for (const match of matches) {
+    const syllables = splitSource(raw, normalized, match.syllables);
+    const key = match.syllables.join(".").toLowerCase() + "/" + match.stress;
+
+    const existing = res.find((reading) => reading.key === key);
+    if (existing) {
+      existing.entries.push(match.entry);
+    } else {
+      res.push({key, syllables, stress: match.stress, entries: [match.entry]});
+    }
+  }
+
+  return res;
+}
+
+function splitSource(raw, normalized, syllables) {
+  if (syllables.join("").toLowerCase() !== normalized.toLowerCase()) {
+    return null;
+  }
+
+  const res = [];
+  let pos = 0;
+  for (const syllable of syllables) {
+    const n = Array.from(syllable).length;
+    res.push(raw.slice(pos, pos + n).join(""));
+    pos += n;
+  }
+
+  return res;
+}
+
+// reading gives the reading to show for the word, or null if there's none or it's not been picked yet.
+function reading(seg) {
+  if (seg.readings.length === 1) {
+    return seg.readings[0];
+  }
+
+  return seg.chosen >= 0 ? seg.readings[seg.chosen] : null;
+}
+
+function render() {
+  output.replaceChildren();
+
+  lines.forEach((line, i) => {
+    if (i > 0) {
+      output.append("\n");
+    }
+
+    for (const seg of line) {
+      if (seg.readings === undefined) {
+        output.append(seg.text);
+        continue;
+      }
+
+      output.append(seg.prefix);
+      output.append(renderWord(seg));
+      if (seg.readings.length > 1) {
+        output.append(renderChoice(seg));
+      }
+    }
+  });
+}
+
+function renderWord(seg) {
+  const span = document.createElement("span");
+  span.className = "word";
+
+  if (seg.readings.length === 0) {
+    span.classList.add("unknown");
+    span.title = "Not found in the dictionary";
+    span.textContent = seg.raw;
+    return span;
+  }
+
+  span.title = seg.readings.map(describe).join("\n");
+  const current = reading(seg);
+  if (current === null || current.syllables === null) {
+    if (current === null) {
+      span.classList.add("ambiguous");
+    }
+    span.textContent = seg.raw;
+    return span;
+  }
+
+  current.syllables.forEach((syllable, i) => {
+    if (i === current.stress) {
+      const stressed = document.createElement("span");
+      stressed.className = "stress";
+      stressed.textContent = syllable;
+      span.append(stressed);
+    } else {
+      span.append(syllable);
+    }
+  });
+
+  return span;
+}
+
+function renderChoice(seg) {
+  const select = document.createElement("select");
+  select.title = "Pick a reading";
+  select.append(new Option("?", "-1"));
+  seg.readings.forEach((r, i) => select.append(new Option(describe(r), String(i))));
+  select.value = String(seg.chosen);
+
+  select.addEventListener("change", () => {
+    seg.chosen = Number(select.value);
+    select.previousSibling.replaceWith(renderWord(seg));
+  });
+
+  return select;
+}
+
+// describe writes a reading like Entry.String writes syllables, e.g. "kal.*txì: hello".
+function describe(r) {
+  const word = r.key.split("/")[0].split(".").map((s, i) => (i > 0 && i === r.stress ? "*" : "") + s).join(".");
+  const translations = r.entries.map((entry) => entry.translation).filter((t) => t);
+
+  return translations.length > 0 ? `${word}: ${translations.join("; ")}` : word;
+}
+
+function copy(asHTML) {
+  const mark = markSelect.value;
+  const plain = marked((syllable) => markSyllable(syllable, mark), (s) => s);
+  if (!asHTML) {
+    navigator.clipboard.writeText(plain).then(() => setStatus("Copied."), (err) => setStatus(err.message, true));
+    return;
+  }
+
+  const html = marked((syllable) => `<u>${escapeHTML(syllable)}</u>`, escapeHTML).replace(/\n/g, "<br>\n");
+  if (!window.ClipboardItem) {
+    navigator.clipboard.writeText(html).then(() => setStatus("Copied the HTML."), (err) => setStatus(err.message, true));
+    return;
+  }
+
+  navigator.clipboard.write([new ClipboardItem({
+    "text/html": new Blob([html], {type: "text/html"}),
+    "text/plain": new Blob([plain], {type: "text/plain"}),
+  })]).then(() => setStatus("Copied as HTML."), (err) => setStatus(err.message, true));
+}
+
+// marked writes the text back out with stressed(syllable) for the stressed syllables, and escape(text) for the rest.
+function marked(stressed, escape) {
+  return lines.map((line) => line.map((seg) => {
+    if (seg.readings === undefined) {
+      return escape(seg.text);
+    }
+
+    const current = reading(seg);
+    if (current === null || current.syllables === null) {
+      return escape(seg.prefix + seg.raw);
+    }
+
+    return escape(seg.prefix) + current.syllables.map((syllable, i) => (
+      i === current.stress ? stressed(syllable) : escape(syllable)
+    )).join("");
+  }).join("")).join("\n");
+}
+
+// markSyllable marks the stress the same ways as the Go formatters. The accent goes on the nucleus like in
+// AccentStress: precomposed where there is such a letter, and a combining accent after ä, ì and ù.
+function markSyllable(syllable, mark) {
+  switch (mark) {
+    case "upper":
+      return syllable.toUpperCase();
+    case "ipa":
+      return "ˈ" + syllable;
+  }
+
+  const vowel = syllable.search(/[aäeéiìoóuùáíú]/i);
+  if (vowel >= 0) {
+    if (/[éÉ]/.test(syllable[vowel])) {
+      return syllable;
+    }
+
+    let end = vowel + 1;
+    while (end < syllable.length && /[\u0300-\u036f]/.test(syllable[end])) {
+      end++;
+    }
+
+    return syllable.slice(0, vowel) + (syllable.slice(vowel, end) + "\u0301").normalize("NFC") + syllable.slice(end);
+  }
+
+  const pseudovowel = syllable.search(/ll|rr/i);
+  if (pseudovowel >= 0) {
+    return syllable.slice(0, pseudovowel) + (syllable[pseudovowel] + "\u0301").normalize("NFC") + syllable.slice(pseudovowel + 1);
+  }
+
+  return syllable;
+}
+
+function escapeHTML(s) {
+  return s.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;").replace(/"/g, "&quot;");
+}
+
+function setStatus(message, isError = false) {
+  status.textContent = message;
+  status.classList.toggle("error", isError);
+}
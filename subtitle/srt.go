@@ -0,0 +1,8 @@
+package subtitle
+
+import "io"
+
+// ReadSRT reads a SubRip file.
+func ReadSRT(r io.Reader) (*Document, error) {
+	return read(r, func([]string) bool { return false })
+}
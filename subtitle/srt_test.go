@@ -0,0 +1,78 @@
+package subtitle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/stretchr/testify/assert"
+)
+
+const srtInput = `1
+00:00:01,000 --> 00:00:02,500
+Kaltxì, ma fmetokyu!
+
+2
+00:00:03,000 --> 00:00:05,000
+<i>Oel ngati kameie.</i>
+Ma tsmukan!
+
+
+3
+00:00:06,000 --> 00:00:07,000 X1:100 X2:200
+{\an8}Kaltxì
+`
+
+const srtExpected = `1
+00:00:01,000 --> 00:00:02,500
+Kal<u>txì</u>, <u>ma</u> <u>fme</u>tokyu!
+
+2
+00:00:03,000 --> 00:00:05,000
+<i><u>O</u>el <u>nga</u>ti <u>ka</u>meie.</i>
+<u>Ma</u> tsmukan!
+
+
+3
+00:00:06,000 --> 00:00:07,000 X1:100 X2:200
+{\an8}Kal<u>txì</u>
+`
+
+func TestReadSRT(t *testing.T) {
+	for _, input := range []string{srtInput, strings.ReplaceAll(srtInput, "\n", "\r\n"), strings.TrimSuffix(srtInput, "\n"), ""} {
+		doc, err := ReadSRT(strings.NewReader(input))
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		n, err := doc.WriteTo(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(input)), n)
+		assert.Equal(t, input, buf.String())
+	}
+}
+
+func TestDocument_Annotate_SRT(t *testing.T) {
+	doc, err := ReadSRT(strings.NewReader(srtInput))
+	assert.NoError(t, err)
+
+	cues := doc.Cues()
+	assert.Len(t, cues, 3)
+	assert.Equal(t, "2", cues[1].ID)
+	assert.Equal(t, "00:00:03,000 --> 00:00:05,000", cues[1].Timing)
+	assert.Equal(t, []string{"<i>Oel ngati kameie.</i>", "Ma tsmukan!"}, cues[1].Text)
+
+	reports, err := doc.Annotate(dictionary, UnderlineStress)
+	assert.NoError(t, err)
+	assert.Equal(t, []CueReport{{Index: 1, ID: "2", Unknown: []string{"tsmukan"}}}, reports)
+
+	buf := bytes.Buffer{}
+	_, err = doc.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, srtExpected, buf.String())
+
+	doc, _ = ReadSRT(strings.NewReader(srtInput))
+	reports, err = doc.Annotate(testdict.Broken{}, UnderlineStress)
+	assert.Error(t, err)
+	assert.Nil(t, reports)
+}
@@ -0,0 +1,212 @@
+// Package subtitle reads and writes SRT and WebVTT files so that the cue text can be stress-marked with litxap.
+package subtitle
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/gissleh/litxap"
+)
+
+// Document is a subtitle file split into blocks. Everything that is not cue text is kept as it was written, so
+// writing a document back gives the exact same bytes unless a cue was changed.
+type Document struct {
+	Blocks []Block
+
+	trailingNewline bool
+}
+
+// Block is either a cue, or a run of lines that are not (blank lines, the WEBVTT header, NOTE, STYLE, ...).
+type Block struct {
+	Cue   *Cue
+	Lines []string
+}
+
+// Cue is a single subtitle. The line endings are kept on each line, so a "\r" may follow the text.
+type Cue struct {
+	// ID is the cue's number in SRT, and the optional identifier in WebVTT.
+	ID string
+	// Timing is the timing line as written, including any WebVTT cue settings.
+	Timing string
+	// Text is the cue's text, one entry per line.
+	Text []string
+}
+
+// CueReport lists the unknown words in a cue.
+type CueReport struct {
+	// Index is the zero-based index of the cue among the cues in the document.
+	Index   int
+	ID      string
+	Unknown []string
+}
+
+// UnderlineStress marks stress with <u> tags, which both SRT and WebVTT players understand.
+var UnderlineStress = litxap.WrapStress("<u>", "</u>")
+
+// Cues lists the cues in the document.
+func (doc *Document) Cues() []*Cue {
+	res := make([]*Cue, 0, len(doc.Blocks)/2)
+	for _, block := range doc.Blocks {
+		if block.Cue != nil {
+			res = append(res, block.Cue)
+		}
+	}
+
+	return res
+}
+
+// Annotate runs the text of each cue through the dictionary, and writes it back with format applied to the matched
+// words. Styling tags and entities are left as they are, and so are the timings and IDs. It returns a report for
+// every cue that had unknown words.
+func (doc *Document) Annotate(dict litxap.Dictionary, format litxap.WordFormatter) ([]CueReport, error) {
+	var reports []CueReport
+
+	for i, cue := range doc.Cues() {
+		var unknown []string
+		for j, text := range cue.Text {
+			newText, textUnknown, err := annotateText(text, dict, format)
+			if err != nil {
+				return nil, err
+			}
+
+			cue.Text[j] = newText
+			unknown = append(unknown, textUnknown...)
+		}
+
+		if len(unknown) > 0 {
+			reports = append(reports, CueReport{Index: i, ID: strings.TrimRight(cue.ID, "\r"), Unknown: unknown})
+		}
+	}
+
+	return reports, nil
+}
+
+// WriteTo writes the document back out.
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	written := int64(0)
+	first := true
+
+	writeLine := func(line string) {
+		if !first {
+			n, _ := bw.WriteString("\n")
+			written += int64(n)
+		}
+		n, _ := bw.WriteString(line)
+		written += int64(n)
+		first = false
+	}
+
+	for _, block := range doc.Blocks {
+		if block.Cue == nil {
+			for _, line := range block.Lines {
+				writeLine(line)
+			}
+			continue
+		}
+
+		if block.Cue.ID != "" {
+			writeLine(block.Cue.ID)
+		}
+		writeLine(block.Cue.Timing)
+		for _, text := range block.Cue.Text {
+			writeLine(text)
+		}
+	}
+	if doc.trailingNewline {
+		n, _ := bw.WriteString("\n")
+		written += int64(n)
+	}
+
+	return written, bw.Flush()
+}
+
+func annotateText(text string, dict litxap.Dictionary, format litxap.WordFormatter) (string, []string, error) {
+	sb := strings.Builder{}
+	var unknown []string
+
+	pos := 0
+	for _, tag := range append(tagRegex.FindAllStringIndex(text, -1), []int{len(text), len(text)}) {
+		if tag[0] > pos {
+			formatted, line, err := litxap.FormatLine(text[pos:tag[0]], dict, format)
+			if err != nil {
+				return "", nil, err
+			}
+
+			sb.WriteString(formatted)
+			unknown = append(unknown, line.Unknown()...)
+		}
+
+		sb.WriteString(text[tag[0]:tag[1]])
+		pos = tag[1]
+	}
+
+	return sb.String(), unknown, nil
+}
+
+func read(r io.Reader, isHeader func(lines []string) bool) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	lines := strings.Split(string(data), "\n")
+	if len(data) > 0 && lines[len(lines)-1] == "" {
+		doc.trailingNewline = true
+		lines = lines[:len(lines)-1]
+	}
+
+	for len(lines) > 0 && len(data) > 0 {
+		if isBlank(lines[0]) {
+			doc.Blocks = append(doc.Blocks, Block{Lines: lines[:1]})
+			lines = lines[1:]
+			continue
+		}
+
+		n := 1
+		for n < len(lines) && !isBlank(lines[n]) {
+			n++
+		}
+
+		doc.Blocks = append(doc.Blocks, parseBlock(lines[:n], isHeader))
+		lines = lines[n:]
+	}
+
+	return doc, nil
+}
+
+func parseBlock(lines []string, isHeader func(lines []string) bool) Block {
+	if isHeader(lines) {
+		return Block{Lines: lines}
+	}
+
+	for i, line := range lines {
+		if i > 1 {
+			break
+		}
+
+		if strings.Contains(line, "-->") {
+			cue := &Cue{Timing: line, Text: append(lines[:0:0], lines[i+1:]...)}
+			if i == 1 {
+				cue.ID = lines[0]
+			}
+
+			return Block{Cue: cue}
+		}
+	}
+
+	return Block{Lines: lines}
+}
+
+func isBlank(line string) bool {
+	return strings.TrimRight(line, "\r") == ""
+}
+
+// tagRegex finds the styling tags (<i>, <c.red>, <00:01.000>, {\an8}) and entities (&amp;) in the cue text.
+var tagRegex = regexp.MustCompile(`<[^>]*>|\{[^}]*\}|&(?:[a-zA-Z]+|#[0-9]+|#x[0-9a-fA-F]+);`)
+
+var ErrMissingHeader = errors.New("missing WEBVTT header")
@@ -0,0 +1,40 @@
+package subtitle
+
+import (
+	"testing"
+
+	"github.com/gissleh/litxap/internal/testdict"
+	"github.com/stretchr/testify/assert"
+)
+
+var dictionary = testdict.New(testdict.Basic, []string{"o.e: -l", "nga: -ti", "k·a.m·e: <ei>"})
+
+func TestAnnotateText(t *testing.T) {
+	table := []struct {
+		input    string
+		expected string
+		unknown  []string
+	}{
+		{"Kaltxì, ma fmetokyu!", "Kal<u>txì</u>, <u>ma</u> <u>fme</u>tokyu!", nil},
+		{"<i>Kaltxì</i>, ma tsmukan!", "<i>Kal<u>txì</u></i>, <u>ma</u> tsmukan!", []string{"tsmukan"}},
+		{"{\\an8}Oel ngati kameie.\r", "{\\an8}<u>O</u>el <u>nga</u>ti <u>ka</u>meie.\r", nil},
+		{"<c.yellow>ma</c> &amp; <00:00:01.500>ma", "<c.yellow><u>ma</u></c> &amp; <00:00:01.500><u>ma</u>", nil},
+		{"", "", nil},
+	}
+
+	for _, row := range table {
+		t.Run(row.input, func(t *testing.T) {
+			res, unknown, err := annotateText(row.input, dictionary, UnderlineStress)
+			assert.NoError(t, err)
+			assert.Equal(t, row.expected, res)
+			assert.Equal(t, row.unknown, unknown)
+		})
+	}
+}
+
+func TestAnnotateText_Fail(t *testing.T) {
+	res, unknown, err := annotateText("<i>Kaltxì</i>", testdict.Broken{}, UnderlineStress)
+	assert.Error(t, err)
+	assert.Empty(t, res)
+	assert.Nil(t, unknown)
+}
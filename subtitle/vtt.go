@@ -0,0 +1,33 @@
+package subtitle
+
+import (
+	"io"
+	"strings"
+)
+
+// ReadVTT reads a WebVTT file. The header, NOTE, STYLE and REGION blocks are kept, but never annotated.
+func ReadVTT(r io.Reader) (*Document, error) {
+	doc, err := read(r, isVTTHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Blocks) == 0 || doc.Blocks[0].Cue != nil || !strings.HasPrefix(strings.TrimPrefix(doc.Blocks[0].Lines[0], "\uFEFF"), "WEBVTT") {
+		return nil, ErrMissingHeader
+	}
+
+	return doc, nil
+}
+
+func isVTTHeader(lines []string) bool {
+	for _, keyword := range vttKeywords {
+		first := strings.TrimPrefix(lines[0], "\uFEFF")
+		if first == keyword || strings.HasPrefix(first, keyword+" ") || strings.HasPrefix(first, keyword+"\t") || strings.HasPrefix(first, keyword+"\r") {
+			return true
+		}
+	}
+
+	return false
+}
+
+var vttKeywords = []string{"WEBVTT", "NOTE", "STYLE", "REGION"}
@@ -0,0 +1,79 @@
+package subtitle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const vttInput = "\uFEFFWEBVTT - Na'vi subtitles\r\n" + `Kind: captions
+
+STYLE
+::cue(.yellow) { color: yellow; }
+
+NOTE Kaltxì should not be touched here.
+
+intro
+00:01.000 --> 00:02.500 line:0 position:20%
+<v Neytiri>Kaltxì, ma fmetokyu!
+
+00:03.000 --> 00:05.000
+<c.yellow>Oel</c> ngati &amp; kameie.
+Tsmukan!
+`
+
+const vttExpected = "\uFEFFWEBVTT - Na'vi subtitles\r\n" + `Kind: captions
+
+STYLE
+::cue(.yellow) { color: yellow; }
+
+NOTE Kaltxì should not be touched here.
+
+intro
+00:01.000 --> 00:02.500 line:0 position:20%
+<v Neytiri>Kal<u>txì</u>, <u>ma</u> <u>fme</u>tokyu!
+
+00:03.000 --> 00:05.000
+<c.yellow><u>O</u>el</c> <u>nga</u>ti &amp; <u>ka</u>meie.
+Tsmukan!
+`
+
+func TestReadVTT(t *testing.T) {
+	doc, err := ReadVTT(strings.NewReader(vttInput))
+	assert.NoError(t, err)
+
+	cues := doc.Cues()
+	assert.Len(t, cues, 2)
+	assert.Equal(t, "intro", cues[0].ID)
+	assert.Equal(t, "00:01.000 --> 00:02.500 line:0 position:20%", cues[0].Timing)
+	assert.Equal(t, "", cues[1].ID)
+
+	buf := bytes.Buffer{}
+	_, err = doc.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, vttInput, buf.String())
+}
+
+func TestReadVTT_MissingHeader(t *testing.T) {
+	for _, input := range []string{"", srtInput, "00:01.000 --> 00:02.500\nKaltxì\n"} {
+		doc, err := ReadVTT(strings.NewReader(input))
+		assert.ErrorIs(t, err, ErrMissingHeader)
+		assert.Nil(t, doc)
+	}
+}
+
+func TestDocument_Annotate_VTT(t *testing.T) {
+	doc, err := ReadVTT(strings.NewReader(vttInput))
+	assert.NoError(t, err)
+
+	reports, err := doc.Annotate(dictionary, UnderlineStress)
+	assert.NoError(t, err)
+	assert.Equal(t, []CueReport{{Index: 1, Unknown: []string{"Tsmukan"}}}, reports)
+
+	buf := bytes.Buffer{}
+	_, err = doc.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, vttExpected, buf.String())
+}
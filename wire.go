@@ -0,0 +1,146 @@
+package litxap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// WireVersion is the version of the LinesEnvelope schema. It is bumped whenever a change would break a client.
+//
+// Fields are added without a bump when old clients can ignore them. Version 1 has gained "errors" on the parts and
+// "partOfSpeech" on the entries this way: both are left out when empty, so the output for a line without them is
+// what it was, and an entry that only an error refers to is just an entry that no match uses.
+const WireVersion = 1
+
+// LinesEnvelope wraps Line results for sending over the wire. Only one of Lines and Compact is set.
+type LinesEnvelope struct {
+	Version int           `json:"version"`
+	Lines   []Line        `json:"lines,omitempty"`
+	Compact *CompactLines `json:"compact,omitempty"`
+}
+
+// CompactLines stores each distinct entry once, and has the matches refer to them by their index in Entries.
+type CompactLines struct {
+	Entries []Entry         `json:"entries"`
+	Lines   [][]CompactPart `json:"lines"`
+}
+
+// CompactPart is a LinePart whose matches refer to entries by index.
+type CompactPart struct {
+	Raw     string         `json:"raw"`
+	Lookup  string         `json:"lookup,omitempty"`
+	IsWord  bool           `json:"isWord,omitempty"`
+	Matches []CompactMatch `json:"matches,omitempty"`
+	Errors  []CompactError `json:"errors,omitempty"`
+}
+
+// CompactMatch is a LinePartMatch with the entry replaced by its index in CompactLines.Entries.
+type CompactMatch struct {
+	Syllables []string `json:"syllables"`
+	Stress    int      `json:"stress"`
+	Entry     int      `json:"entry"`
+}
+
+// CompactError is a MatchError with the entry replaced by its index in CompactLines.Entries.
+type CompactError struct {
+	Entry   int    `json:"entry"`
+	Message string `json:"message"`
+}
+
+// EncodeLines puts the lines in an envelope of the current version. In compact mode, the entries are deduplicated.
+func EncodeLines(lines []Line, compact bool) LinesEnvelope {
+	if !compact {
+		return LinesEnvelope{Version: WireVersion, Lines: lines}
+	}
+
+	res := &CompactLines{Lines: make([][]CompactPart, 0, len(lines))}
+	seen := make(map[string][]int)
+	for _, line := range lines {
+		parts := make([]CompactPart, 0, len(line))
+		for _, part := range line {
+			cPart := CompactPart{Raw: part.Raw, Lookup: part.Lookup, IsWord: part.IsWord}
+			for _, match := range part.Matches {
+				cPart.Matches = append(cPart.Matches, CompactMatch{
+					Syllables: match.Syllables,
+					Stress:    match.Stress,
+					Entry:     res.entryIndex(match.Entry, seen),
+				})
+			}
+			for _, matchErr := range part.Errors {
+				cPart.Errors = append(cPart.Errors, CompactError{
+					Entry:   res.entryIndex(matchErr.Entry, seen),
+					Message: matchErr.Message,
+				})
+			}
+
+			parts = append(parts, cPart)
+		}
+
+		res.Lines = append(res.Lines, parts)
+	}
+
+	return LinesEnvelope{Version: WireVersion, Compact: res}
+}
+
+// Decode gets the lines back out of the envelope, whether it's compact or not.
+func (env *LinesEnvelope) Decode() ([]Line, error) {
+	if env.Version != WireVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, env.Version)
+	}
+	if env.Compact == nil {
+		return env.Lines, nil
+	}
+
+	lines := make([]Line, 0, len(env.Compact.Lines))
+	for _, cLine := range env.Compact.Lines {
+		line := make(Line, 0, len(cLine))
+		for _, cPart := range cLine {
+			part := LinePart{Raw: cPart.Raw, Lookup: cPart.Lookup, IsWord: cPart.IsWord}
+			for _, cMatch := range cPart.Matches {
+				if cMatch.Entry < 0 || cMatch.Entry >= len(env.Compact.Entries) {
+					return nil, fmt.Errorf("%w: %d", ErrInvalidEntryIndex, cMatch.Entry)
+				}
+
+				part.Matches = append(part.Matches, LinePartMatch{
+					Syllables: cMatch.Syllables,
+					Stress:    cMatch.Stress,
+					Entry:     env.Compact.Entries[cMatch.Entry],
+				})
+			}
+			for _, cErr := range cPart.Errors {
+				if cErr.Entry < 0 || cErr.Entry >= len(env.Compact.Entries) {
+					return nil, fmt.Errorf("%w: %d", ErrInvalidEntryIndex, cErr.Entry)
+				}
+
+				part.Errors = append(part.Errors, MatchError{
+					Entry:   env.Compact.Entries[cErr.Entry],
+					Message: cErr.Message,
+				})
+			}
+
+			line = append(line, part)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+func (cl *CompactLines) entryIndex(entry Entry, seen map[string][]int) int {
+	// Entries aren't comparable, so the notation narrows it down before they're compared in full.
+	key := entry.String()
+	for _, i := range seen[key] {
+		if reflect.DeepEqual(cl.Entries[i], entry) {
+			return i
+		}
+	}
+
+	cl.Entries = append(cl.Entries, entry)
+	seen[key] = append(seen[key], len(cl.Entries)-1)
+	return len(cl.Entries) - 1
+}
+
+var ErrUnsupportedVersion = errors.New("unsupported wire version")
+var ErrInvalidEntryIndex = errors.New("invalid entry index")
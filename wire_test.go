@@ -0,0 +1,118 @@
+package litxap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeLines(t *testing.T) {
+	var lines []Line
+	for _, input := range []string{"Kaltxì, ma fmetokyu!", "Ma fmetokyu, oel ngati kameie.", "Vola skeynven."} {
+		line, err := RunLine(input, dummyDictionary)
+		assert.NoError(t, err)
+		lines = append(lines, line)
+	}
+
+	for _, compact := range []bool{false, true} {
+		env := EncodeLines(lines, compact)
+		assert.Equal(t, WireVersion, env.Version)
+
+		data, err := json.Marshal(env)
+		assert.NoError(t, err)
+
+		var decodedEnv LinesEnvelope
+		assert.NoError(t, json.Unmarshal(data, &decodedEnv))
+
+		decoded, err := decodedEnv.Decode()
+		assert.NoError(t, err)
+		assert.Equal(t, lines, decoded)
+
+		if compact {
+			assert.Nil(t, env.Lines)
+			assert.Len(t, env.Compact.Entries, 7)
+			assert.Equal(t, 1, env.Compact.Lines[1][0].Matches[0].Entry)
+			assert.Equal(t, 2, env.Compact.Lines[1][2].Matches[0].Entry)
+		} else {
+			assert.Nil(t, env.Compact)
+		}
+	}
+}
+
+func TestEncodeLines_Errors(t *testing.T) {
+	broken := Entry{Word: "ma", Syllables: []string{"ma"}, Suffixes: []string{"teriri"}}
+	line := Line{{Raw: "ma", IsWord: true, Errors: []MatchError{{Entry: broken, Message: "broken"}}}}
+
+	env := EncodeLines([]Line{line}, true)
+	assert.Equal(t, []Entry{broken}, env.Compact.Entries)
+	assert.Equal(t, []CompactError{{Entry: 0, Message: "broken"}}, env.Compact.Lines[0][0].Errors)
+
+	decoded, err := env.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, []Line{line}, decoded)
+
+	env.Compact.Lines[0][0].Errors[0].Entry = 3
+	_, err = env.Decode()
+	assert.ErrorIs(t, err, ErrInvalidEntryIndex)
+}
+
+func TestEncodeLines_Schema(t *testing.T) {
+	line, err := RunLine("Ma ma!", dummyDictionary)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(EncodeLines([]Line{line}, true))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"compact": {
+			"entries": [{"word": "ma", "translation": "", "syllables": ["ma"], "stress": 0}],
+			"lines": [[
+				{"raw": "Ma", "isWord": true, "matches": [{"syllables": ["Ma"], "stress": 0, "entry": 0}]},
+				{"raw": " "},
+				{"raw": "ma", "isWord": true, "matches": [{"syllables": ["ma"], "stress": 0, "entry": 0}]},
+				{"raw": "!"}
+			]]
+		}
+	}`, string(data))
+}
+
+func TestLinesEnvelope_Decode_Additions(t *testing.T) {
+	// A payload from before "errors" and "partOfSpeech" were added still decodes, and one with them is still version 1.
+	var env LinesEnvelope
+	assert.NoError(t, json.Unmarshal([]byte(`{"version": 1, "compact": {"entries": [{"word": "ma", "syllables": ["ma"], "stress": 0}], "lines": [[{"raw": "ma", "isWord": true, "matches": [{"syllables": ["ma"], "stress": 0, "entry": 0}]}]]}}`), &env))
+	lines, err := env.Decode()
+	assert.NoError(t, err)
+	assert.Len(t, lines[0][0].Matches, 1)
+	assert.Empty(t, lines[0][0].Errors)
+
+	broken := Entry{Word: "ma", Syllables: []string{"ma"}, Suffixes: []string{"teriri"}, PartOfSpeech: "part."}
+	env = EncodeLines([]Line{{{Raw: "ma", IsWord: true, Errors: []MatchError{{Entry: broken, Message: "bad"}}}}}, true)
+	assert.Equal(t, 1, env.Version)
+	assert.Empty(t, env.Compact.Lines[0][0].Matches)
+	assert.Equal(t, []Entry{broken}, env.Compact.Entries)
+}
+
+func TestLinesEnvelope_Decode_Fail(t *testing.T) {
+	table := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"NoVersion", `{"lines": []}`, ErrUnsupportedVersion},
+		{"FutureVersion", `{"version": 2, "lines": []}`, ErrUnsupportedVersion},
+		{"BadIndex", `{"version": 1, "compact": {"entries": [], "lines": [[{"raw": "ma", "isWord": true, "matches": [{"syllables": ["ma"], "stress": 0, "entry": 0}]}]]}}`, ErrInvalidEntryIndex},
+		{"NegativeIndex", `{"version": 1, "compact": {"entries": [], "lines": [[{"raw": "ma", "isWord": true, "matches": [{"syllables": ["ma"], "stress": 0, "entry": -1}]}]]}}`, ErrInvalidEntryIndex},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			var env LinesEnvelope
+			assert.NoError(t, json.Unmarshal([]byte(row.input), &env))
+
+			lines, err := env.Decode()
+			assert.ErrorIs(t, err, row.err)
+			assert.Nil(t, lines)
+		})
+	}
+}
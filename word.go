@@ -8,3 +8,26 @@ func RunWord(word string, entry Entry) ([]string, int) {
 	syllables, stress, root := entry.GenerateSyllables()
 	return litxaputil.MatchSyllables(word, syllables, root, stress)
 }
+
+// RunWordE is RunWord for entries that can't be trusted. If the entry's syllables can't be generated, it returns the
+// error from Entry.GenerateSyllablesE.
+func RunWordE(word string, entry Entry) ([]string, int, error) {
+	return runWordE(word, entry, litxaputil.DialectAny)
+}
+
+// RunWordIn is RunWord for a word in the dialect. The entry's syllables are changed to fit the dialect after all the
+// affixes are added, e.g. "tsmukangamlä" matches "tsmu.kan: -kxamlä" in Reef Na'vi, but not in Forest Na'vi.
+func RunWordIn(word string, entry Entry, dialect litxaputil.Dialect) ([]string, int) {
+	syllables, stress, _ := runWordE(word, entry, dialect)
+	return syllables, stress
+}
+
+func runWordE(word string, entry Entry, dialect litxaputil.Dialect) ([]string, int, error) {
+	syllables, stress, root, err := entry.GenerateSyllablesE()
+	if err != nil {
+		return nil, -1, err
+	}
+
+	syllables, stress = litxaputil.MatchSyllablesIn(word, syllables, root, stress, dialect)
+	return syllables, stress, nil
+}
@@ -1,9 +1,11 @@
 package litxap
 
 import (
-	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+
+	"github.com/gissleh/litxap/litxaputil"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRunWord(t *testing.T) {
@@ -49,6 +51,98 @@ func TestRunWord(t *testing.T) {
 			Raw: "ayskxe", Entry: "tskxe: ay-",
 			Res: "ay.skxe", ResStress: 1,
 		},
+		{
+			Raw: "mesute", Entry: "tu.te: me-",
+			Res: "me.su.te", ResStress: 1,
+		},
+		{
+			Raw: "pepefnesìfmetok", Entry: "tì.*fme.tok: pe-pxe-fne-",
+			Res: "pe.pe.fne.sì.fme.tok", ResStress: 4,
+		},
+		{
+			Raw: "pehem", Entry: "kem: pe-",
+			Res: "pe.hem", ResStress: 1,
+		},
+		{
+			Raw: "pefnehem", Entry: "kem: pe-fne-",
+			Res: "pe.fne.hem", ResStress: 2,
+		},
+		{
+			Raw: "alusìng", Entry: "lu.*sìng: a-",
+			Res: "a.lu.sìng", ResStress: 2,
+		},
+		{
+			Raw: "sìltsana", Entry: "sìl.tsan: -a",
+			Res: "sìl.tsa.na", ResStress: 0,
+		},
+		{
+			Raw: "aean", Entry: "e.*an: a-",
+			Res: "a.e.an", ResStress: 2,
+		},
+		{
+			Raw: "lefpom", Entry: "fpom: adj. le-",
+			Res: "le.fpom", ResStress: 1,
+		},
+		{
+			Raw: "asìltsan", Entry: "sìl.tsan: adj. a-",
+			Res: "a.sìl.tsan", ResStress: 1,
+		},
+		{
+			Raw: "alefpoma", Entry: "fpom: a-le- -a",
+			Res: "a.le.fpo.ma", ResStress: 2,
+		},
+		{
+			Raw: "aeana", Entry: "e.*an: a- -a",
+			Res: "a.e.a.na", ResStress: 2,
+		},
+		{
+			Raw: "leean", Entry: "e.*an: le-",
+			Res: "le.e.an", ResStress: 2,
+		},
+		{
+			Raw: "ngaya", Entry: "ngay: -a",
+			Res: "nga.ya", ResStress: 0,
+		},
+		{
+			Raw: "apxaa", Entry: "ap.xa: -a",
+			Res: "ap.xa.a", ResStress: 0,
+		},
+		{
+			Raw: "Meylan", Entry: "'ey.lan: me-",
+			Res: "Mey.lan", ResStress: 0,
+		},
+		{
+			Raw: "aysusahey", Entry: "ts·a.*h·ey: ay- <us>",
+			Res: "ay.su.sa.hey", ResStress: 3,
+		},
+		{
+			Raw: "mekolatsapey", Entry: "kx·a.*ts·a.p·ey: me- <ol>",
+			Res: "me.ko.la.tsa.pey", ResStress: 3,
+		},
+		{
+			Raw: "fìkem", Entry: "kem: *fì-",
+			Res: "fì.kem", ResStress: 0,
+		},
+		{
+			Raw: "tsatseng", Entry: "tseng: *tsa-",
+			Res: "tsa.tseng", ResStress: 0,
+		},
+		{
+			Raw: "fìtseng", Entry: "tseng: fì-",
+			Res: "fì.tseng", ResStress: 1,
+		},
+		{
+			Raw: "fìtseng", Entry: "tseng: fì<-",
+			Res: "fì.tseng", ResStress: 0,
+		},
+		{
+			Raw: "fmetokyu", Entry: "fme.tok: -yu>",
+			Res: "fme.tok.yu", ResStress: 1,
+		},
+		{
+			Raw: "fnetaron", Entry: "ta.ron: fne-",
+			Res: "", ResStress: -1,
+		},
 		{
 			Raw: "tanlokxe", Entry: "txan.lo.*kxe",
 			Res: "tan.lo.kxe", ResStress: 2,
@@ -69,3 +163,43 @@ func TestRunWord(t *testing.T) {
 		})
 	}
 }
+
+func TestRunWordIn(t *testing.T) {
+	table := []struct {
+		Raw       string
+		Entry     string
+		Dialect   litxaputil.Dialect
+		Res       string
+		ResStress int
+	}{
+		{
+			Raw: "tsmukangamlä", Entry: "tsmu.kan: -kxamlä", Dialect: litxaputil.DialectReef,
+			Res: "tsmu.kan.gam.lä", ResStress: 0,
+		},
+		{
+			Raw: "tsmukangamlä", Entry: "tsmu.kan: -kxamlä", Dialect: litxaputil.DialectForest,
+			Res: "", ResStress: -1,
+		},
+		{
+			Raw: "tsmukangamlä", Entry: "tsmu.kan: -kxamlä", Dialect: litxaputil.DialectAny,
+			Res: "tsmu.kan.gam.lä", ResStress: 0,
+		},
+		{
+			Raw: "Bedukx", Entry: "txukx: pxe-", Dialect: litxaputil.DialectReef,
+			Res: "", ResStress: -1,
+		},
+		{
+			Raw: "Betukx", Entry: "txukx: pxe-", Dialect: litxaputil.DialectReef,
+			Res: "Be.tukx", ResStress: 1,
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.Raw+" "+row.Dialect.String(), func(t *testing.T) {
+			res, resStress := RunWordIn(row.Raw, *ParseEntry(row.Entry), row.Dialect)
+
+			assert.Equal(t, row.Res, strings.Join(res, "."))
+			assert.Equal(t, row.ResStress, resStress)
+		})
+	}
+}